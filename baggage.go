@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"context"
+	"sort"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// BaggageKey is the context key of the W3C baggage logged by WithBaggage
+//
+// The value must be a map[string]string of baggage members. With OpenTelemetry, copy the
+// members of baggage.FromContext(ctx) into such a map, which keeps this package free of
+// the dependency.
+const BaggageKey = "baggage"
+
+// baggageField is the object holding the logged baggage members
+type baggageField struct {
+	members map[string]string
+	keys    []string // Keys logged, sorted and limited to the maximum
+}
+
+// WithBaggage logs the baggage found in the context under a "baggage" object
+//
+// Only the first maxKeys members in key order are logged to keep entries small;
+// maxKeys <= 0 logs every member. Entries whose context carries no baggage are unchanged.
+//
+// Parameters:
+//   - maxKeys: The maximum number of baggage members logged per entry
+//
+// Returns:
+//   - Option: A function that enables baggage logging in the option struct
+func WithBaggage(maxKeys int) Option {
+	return func(o *option) {
+		o.baggage = true
+		o.baggageMaxKeys = maxKeys
+	}
+}
+
+// getBaggageFromContext returns the baggage field of the context, false if it has no baggage
+//
+// Parameters:
+//   - ctx: The context.Context to extract the baggage from
+//   - maxKeys: The maximum number of members logged, <= 0 for no limit
+//
+// Returns:
+//   - zap.Field: The "baggage" object field
+//   - bool: Whether the context carries baggage
+func getBaggageFromContext(ctx context.Context, maxKeys int) (zap.Field, bool) {
+	members, ok := ctx.Value(BaggageKey).(map[string]string)
+	if !ok || len(members) == 0 {
+		return zap.Skip(), false
+	}
+
+	keys := make([]string, 0, len(members))
+	for key := range members {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if maxKeys > 0 && len(keys) > maxKeys {
+		keys = keys[:maxKeys]
+	}
+
+	return zap.Object(BaggageKey, baggageField{members: members, keys: keys}), true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler
+func (b baggageField) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, key := range b.keys {
+		enc.AddString(key, b.members[key])
+	}
+	return nil
+}
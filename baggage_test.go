@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithBaggage(t *testing.T) {
+	baggage := map[string]string{"tenant": "acme", "region": "eu-west-1", "plan": "pro"}
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		ctx     context.Context
+		want    interface{}
+		present bool
+	}{
+		{
+			name:    "all members",
+			opts:    []Option{WithBaggage(0)},
+			ctx:     context.WithValue(context.Background(), BaggageKey, baggage),
+			want:    map[string]interface{}{"tenant": "acme", "region": "eu-west-1", "plan": "pro"},
+			present: true,
+		},
+		{
+			name:    "limited to the first keys",
+			opts:    []Option{WithBaggage(2)},
+			ctx:     context.WithValue(context.Background(), BaggageKey, baggage),
+			want:    map[string]interface{}{"plan": "pro", "region": "eu-west-1"},
+			present: true,
+		},
+		{
+			name: "no baggage in context",
+			opts: []Option{WithBaggage(0)},
+			ctx:  context.Background(),
+		},
+		{
+			name: "disabled",
+			ctx:  context.WithValue(context.Background(), BaggageKey, baggage),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opt := &option{}
+			for _, f := range tt.opts {
+				f(opt)
+			}
+
+			core, recorded := observer.New(zapcore.InfoLevel)
+			logger := &Manager{Zap: zap.New(core), opt: opt}
+			logger.Info(tt.ctx, "checkout")
+
+			require.Equal(t, 1, recorded.Len())
+			got, present := recorded.All()[0].ContextMap()[BaggageKey]
+			assert.Equal(t, tt.present, present)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
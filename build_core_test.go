@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestBuildCore(t *testing.T) {
+	managerOut := &bytes.Buffer{}
+	logger, err := New(WithLevel("error"), WithSinkEncoded("json", false, managerOut, zapcore.InfoLevel), WithDefaultFieldsFunc(func() []zap.Field { return []zap.Field{zap.String("service", "orders")} }))
+	require.NoError(t, err)
+	defer logger.Close()
+
+	coreOut := &bytes.Buffer{}
+	core, level, err := BuildCore(WithLevel("error"), WithSinkEncoded("json", false, coreOut, zapcore.InfoLevel), WithDefaultFieldsFunc(func() []zap.Field { return []zap.Field{zap.String("service", "orders")} }))
+	require.NoError(t, err)
+	assert.Equal(t, zapcore.ErrorLevel, level.Level())
+
+	logger.Info(context.Background(), "order created", zap.String("order", "o-1"))
+	zap.New(core).Info("order created", zap.String("order", "o-1"))
+
+	assert.Equal(t, decodeWithoutTime(t, managerOut.Bytes()), decodeWithoutTime(t, coreOut.Bytes()))
+}
+
+func TestBuildCore_Error(t *testing.T) {
+	_, _, err := BuildCore(WithDriver("unknown"))
+	assert.Error(t, err)
+}
+
+// decodeWithoutTime decodes a JSON entry, dropping its time and caller
+func decodeWithoutTime(t *testing.T, line []byte) map[string]interface{} {
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(line, &entry))
+	delete(entry, "T")
+	delete(entry, "C")
+
+	return entry
+}
@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"context"
+	"runtime"
+	"runtime/debug"
+
+	"go.uber.org/zap"
+)
+
+// readBuildInfo returns the build information embedded in the binary, replaced in tests
+var readBuildInfo = debug.ReadBuildInfo
+
+// WithBuildInfo adds the binary's version and VCS revision to every entry
+//
+// The "version" (main module version), "vcs.revision" and "vcs.time" fields are read from
+// runtime/debug.ReadBuildInfo. Fields that are not available, e.g. when the binary was built
+// without VCS stamping, are omitted.
+//
+// Returns:
+//   - Option: A function that enables the build info fields in the option struct
+func WithBuildInfo() Option {
+	return func(o *option) {
+		o.buildInfo = true
+	}
+}
+
+// buildInfoFields returns the fields describing the build, nil if no build info is embedded
+//
+// Returns:
+//   - []zap.Field: The version, VCS revision and VCS time fields that are available
+func buildInfoFields() []zap.Field {
+	info, ok := readBuildInfo()
+	if !ok {
+		return nil
+	}
+
+	var fields []zap.Field
+	if info.Main.Version != "" {
+		fields = append(fields, zap.String("version", info.Main.Version))
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision", "vcs.time":
+			fields = append(fields, zap.String(setting.Key, setting.Value))
+		}
+	}
+
+	return fields
+}
+
+// LogStartupBanner logs a single Info entry summarizing the build and the configuration
+//
+// The entry carries the Go version, the build fields described in WithBuildInfo (unless
+// that option already adds them to every entry) and the effective Config.
+//
+// Parameters:
+//   - ctx: The context.Context for this log entry
+func (m *Manager) LogStartupBanner(ctx context.Context) {
+	goVersion := runtime.Version()
+	if info, ok := readBuildInfo(); ok && info.GoVersion != "" {
+		goVersion = info.GoVersion
+	}
+
+	fields := []zap.Field{zap.String("go_version", goVersion)}
+	if m.opt == nil || !m.opt.buildInfo {
+		fields = append(fields, buildInfoFields()...)
+	}
+	fields = append(fields, zap.Any("config", m.Config()))
+
+	logger := m.getLoggerWithTraceID(ctx, fields...)
+	logger.Info("startup", fields...)
+}
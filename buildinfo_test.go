@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"context"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// fakeBuildInfo replaces the build info for the duration of the test
+func fakeBuildInfo(t *testing.T, info *debug.BuildInfo) {
+	original := readBuildInfo
+	readBuildInfo = func() (*debug.BuildInfo, bool) { return info, info != nil }
+	t.Cleanup(func() { readBuildInfo = original })
+}
+
+func TestWithBuildInfo(t *testing.T) {
+	fakeBuildInfo(t, &debug.BuildInfo{
+		GoVersion: "go1.22.5",
+		Main:      debug.Module{Path: "github.com/acme/api", Version: "v1.4.2"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs", Value: "git"},
+			{Key: "vcs.revision", Value: "0123abcd"},
+			{Key: "vcs.time", Value: "2024-05-01T10:00:00Z"},
+		},
+	})
+
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger, err := New(WithBuildInfo(), WithExtraCore(core))
+	require.NoError(t, err)
+
+	logger.Info(context.Background(), "request")
+	logger.LogStartupBanner(context.Background())
+
+	entries := recorded.All()
+	require.Len(t, entries, 2)
+	for _, entry := range entries {
+		fields := entry.ContextMap()
+		assert.Equal(t, "v1.4.2", fields["version"])
+		assert.Equal(t, "0123abcd", fields["vcs.revision"])
+		assert.Equal(t, "2024-05-01T10:00:00Z", fields["vcs.time"])
+		assert.NotContains(t, fields, "vcs")
+	}
+
+	banner := entries[1]
+	assert.Equal(t, "startup", banner.Message)
+	assert.Equal(t, "go1.22.5", banner.ContextMap()["go_version"])
+	assert.Equal(t, logger.Config(), banner.ContextMap()["config"])
+
+	versions := 0
+	for _, f := range banner.Context {
+		if f.Key == "version" {
+			versions++
+		}
+	}
+	assert.Equal(t, 1, versions, "the banner does not repeat the build fields")
+}
+
+func TestWithBuildInfo_Unavailable(t *testing.T) {
+	fakeBuildInfo(t, nil)
+
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger, err := New(WithBuildInfo(), WithExtraCore(core))
+	require.NoError(t, err)
+
+	logger.LogStartupBanner(context.Background())
+
+	require.Equal(t, 1, recorded.Len())
+	fields := recorded.All()[0].ContextMap()
+	assert.NotContains(t, fields, "version")
+	assert.NotEmpty(t, fields["go_version"])
+}
@@ -0,0 +1,214 @@
+package logger
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	// structuredCallerCore is a zapcore.Core writing the caller as separate fields
+	structuredCallerCore struct {
+		zapcore.Core
+		levelChecked
+	}
+
+	// callerLevelCore is a zapcore.Core omitting the caller of entries below a level
+	callerLevelCore struct {
+		zapcore.Core
+		levelChecked
+		minLevel zapcore.Level
+	}
+)
+
+// WithFullCaller writes the caller as the absolute file path instead of the last directory
+//
+// Returns:
+//   - Option: A function that sets the caller encoder in the option struct
+func WithFullCaller() Option {
+	return func(o *option) {
+		o.encoderConfig.EncodeCaller = zapcore.FullCallerEncoder
+	}
+}
+
+// WithPackageCaller writes the caller as the full import path of its package
+//
+// For example "github.com/acme/api/handler/user.go:42" rather than "handler/user.go:42",
+// which stays unambiguous when several packages share a directory name.
+//
+// Returns:
+//   - Option: A function that sets the caller encoder in the option struct
+func WithPackageCaller() Option {
+	return func(o *option) {
+		o.encoderConfig.EncodeCaller = PackageCallerEncoder
+	}
+}
+
+// WithCallerFunction writes the caller's fully qualified function name under "func"
+//
+// Line numbers shift with every refactor, the function name usually does not. The name is
+// resolved from the caller's PC when the entry is created.
+//
+// Returns:
+//   - Option: A function that sets the function key in the option struct
+func WithCallerFunction() Option {
+	return func(o *option) {
+		o.encoderConfig.FunctionKey = "func"
+	}
+}
+
+// WithStructuredCaller writes the caller as separate "file", "line" and "function" fields
+//
+// The single caller string is replaced by the absolute file path, the line number and the
+// fully qualified function name, which makes filtering by file or function trivial.
+//
+// Returns:
+//   - Option: A function that enables structured caller fields in the option struct
+func WithStructuredCaller() Option {
+	return func(o *option) {
+		o.structuredCaller = true
+	}
+}
+
+// WithCallerMinLevel only writes the caller of entries at or above the given level
+//
+// High-volume Debug and Info lines are written without the "C" field, and without the
+// function or structured caller fields, which saves encoding them and keeps the lines
+// short; warnings and errors keep their caller. Caller sampling and throttling still
+// group every entry by its caller.
+//
+// Parameters:
+//   - level: The minimum level of the entries written with their caller
+//
+// Returns:
+//   - Option: A function that sets the caller level in the option struct
+func WithCallerMinLevel(level zapcore.Level) Option {
+	return func(o *option) {
+		o.callerMinLevel = &level
+	}
+}
+
+// PackageCallerEncoder serializes a caller as import-path/file.go:line
+//
+// The import path is derived from the caller's function name. Callers without a
+// function name fall back to zapcore.FullCallerEncoder.
+//
+// Parameters:
+//   - caller: The caller to encode
+//   - enc: The encoder to append to
+func PackageCallerEncoder(caller zapcore.EntryCaller, enc zapcore.PrimitiveArrayEncoder) {
+	pkg := callerPackage(caller.Function)
+	if !caller.Defined || pkg == "" {
+		zapcore.FullCallerEncoder(caller, enc)
+		return
+	}
+
+	enc.AppendString(pkg + "/" + filepath.Base(caller.File) + ":" + strconv.Itoa(caller.Line))
+}
+
+// callerPackage returns the import path of the package a function belongs to
+//
+// Parameters:
+//   - function: A fully qualified function name such as "github.com/acme/api/handler.(*User).Get"
+//
+// Returns:
+//   - string: The package import path, e.g. "github.com/acme/api/handler"
+func callerPackage(function string) string {
+	slash := strings.LastIndex(function, "/")
+	dot := strings.Index(function[slash+1:], ".")
+	if dot < 0 {
+		return ""
+	}
+
+	return function[:slash+1+dot]
+}
+
+// newStructuredCallerCore wraps a core to write the caller as separate fields
+//
+// Parameters:
+//   - core: The core to wrap
+//
+// Returns:
+//   - zapcore.Core: A core writing "file", "line" and "function" fields
+func newStructuredCallerCore(core zapcore.Core) zapcore.Core {
+	return &structuredCallerCore{Core: core}
+}
+
+// With adds structured context to the core
+func (c *structuredCallerCore) With(fields []zapcore.Field) zapcore.Core {
+	return &structuredCallerCore{Core: c.Core.With(fields)}
+}
+
+// Check adds the core so Write sees the caller, which zap resolves after Check
+func (c *structuredCallerCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write replaces the entry's caller with the caller fields
+func (c *structuredCallerCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if !ent.Caller.Defined {
+		return writeEntry(c.Core, ent, fields)
+	}
+
+	function := ent.Caller.Function
+	if function == "" {
+		if fn := runtime.FuncForPC(ent.Caller.PC); fn != nil {
+			function = fn.Name()
+		}
+	}
+
+	// Prepend the caller fields so they stay outside any namespace opened by the fields
+	all := make([]zapcore.Field, 0, len(fields)+3)
+	all = append(all,
+		zap.String("file", ent.Caller.File),
+		zap.Int("line", ent.Caller.Line),
+		zap.String("function", function),
+	)
+	all = append(all, fields...)
+	ent.Caller = zapcore.EntryCaller{}
+
+	return writeEntry(c.Core, ent, all)
+}
+
+// newCallerLevelCore wraps a core to omit the caller of entries below a level
+//
+// Parameters:
+//   - core: The core to wrap
+//   - minLevel: The minimum level of the entries written with their caller
+//
+// Returns:
+//   - zapcore.Core: A core omitting the caller of lower entries
+func newCallerLevelCore(core zapcore.Core, minLevel zapcore.Level) zapcore.Core {
+	return &callerLevelCore{Core: core, minLevel: minLevel}
+}
+
+// With adds structured context to the core
+func (c *callerLevelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &callerLevelCore{Core: c.Core.With(fields), minLevel: c.minLevel}
+}
+
+// Check adds the core so Write sees the caller, which zap resolves after Check
+func (c *callerLevelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write clears the caller of entries below the minimum level
+func (c *callerLevelCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if ent.Level < c.minLevel {
+		ent.Caller = zapcore.EntryCaller{}
+	}
+
+	return writeEntry(c.Core, ent, fields)
+}
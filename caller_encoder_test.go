@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestCallerEncoders(t *testing.T) {
+	caller := zapcore.EntryCaller{
+		Defined:  true,
+		File:     "/home/dev/src/api/handler/user.go",
+		Line:     42,
+		Function: "github.com/acme/api/handler.(*User).Get",
+	}
+
+	tests := []struct {
+		name string
+		opt  Option
+		want string
+	}{
+		{"Short", WithEncoderConfig(DefaultEncoderConfig), "handler/user.go:42"},
+		{"Full", WithFullCaller(), "/home/dev/src/api/handler/user.go:42"},
+		{"Package", WithPackageCaller(), "github.com/acme/api/handler/user.go:42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opt := &option{encoderConfig: DefaultEncoderConfig}
+			tt.opt(opt)
+
+			enc := zapcore.NewMapObjectEncoder()
+			_ = enc.AddArray("caller", zapcore.ArrayMarshalerFunc(func(arr zapcore.ArrayEncoder) error {
+				opt.encoderConfig.EncodeCaller(caller, arr)
+				return nil
+			}))
+			assert.Equal(t, []interface{}{tt.want}, enc.Fields["caller"])
+		})
+	}
+}
+
+func TestCallerPackage(t *testing.T) {
+	assert.Equal(t, "github.com/acme/api/handler", callerPackage("github.com/acme/api/handler.(*User).Get"))
+	assert.Equal(t, "main", callerPackage("main.main"))
+	assert.Equal(t, "github.com/acme/api/handler", callerPackage("github.com/acme/api/handler.init.func1"))
+	assert.Equal(t, "", callerPackage(""))
+}
+
+func TestWithStructuredCaller(t *testing.T) {
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger := zap.New(wrapCore(&option{structuredCaller: true}, core), zap.AddCaller())
+
+	_, file, line, _ := runtime.Caller(0)
+	logger.Info("structured caller", zap.Namespace("request"), zap.String("id", "r-1"))
+
+	require.Equal(t, 1, recorded.Len())
+	entry := recorded.All()[0]
+	assert.False(t, entry.Caller.Defined)
+
+	fields := entry.ContextMap()
+	assert.Equal(t, file, fields["file"])
+	assert.Equal(t, int64(line+1), fields["line"])
+	assert.Equal(t, "github.com/sk-pkg/logger.TestWithStructuredCaller", fields["function"])
+	assert.Equal(t, map[string]interface{}{"id": "r-1"}, fields["request"])
+}
+
+// callerFunctionHelper logs from a function with a known name
+func callerFunctionHelper(logger *zap.Logger) {
+	logger.Info("from helper")
+}
+
+func TestWithCallerFunction(t *testing.T) {
+	opt := &option{encoderConfig: DefaultEncoderConfig}
+	WithCallerFunction()(opt)
+
+	out := &bytes.Buffer{}
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(opt.encoderConfig), zapcore.AddSync(out), InfoLevel)
+	callerFunctionHelper(zap.New(core, zap.AddCaller()))
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	assert.Equal(t, "github.com/sk-pkg/logger.callerFunctionHelper", got["func"])
+	assert.Contains(t, got["C"], "caller_encoder_test.go:")
+}
+
+func TestWithCallerMinLevel(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger, err := New(WithCallerMinLevel(zapcore.WarnLevel), WithStructuredCaller(), WithSinkEncoded("json", false, out, zapcore.InfoLevel))
+	require.NoError(t, err)
+	defer logger.Close()
+
+	logger.Info(context.Background(), "order created")
+	logger.Warn(context.Background(), "stock low")
+
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(lines[0], &entry))
+	assert.NotContains(t, entry, "file", "no caller below the minimum level")
+	assert.NotContains(t, entry, "function")
+
+	entry = nil
+	require.NoError(t, json.Unmarshal(lines[1], &entry))
+	assert.Contains(t, entry["file"], "caller_encoder_test.go")
+	assert.Contains(t, entry["function"], "TestWithCallerMinLevel")
+}
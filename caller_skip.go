@@ -18,7 +18,11 @@ func (c CallerSkip) Set(skip int) {
 	c.skip.Store(int32(skip))
 }
 
-// Load loads the number of frames to skip
+// Load loads the number of frames to skip, the zero CallerSkip skips no frames
 func (c CallerSkip) Load() int {
+	if c.skip == nil {
+		return 0
+	}
+
 	return int(c.skip.Load())
 }
@@ -0,0 +1,153 @@
+package logger
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type (
+	// captureSet holds the captures opened by CaptureLogs
+	captureSet struct {
+		mu     sync.Mutex                         // Serializes updates of active
+		active atomic.Pointer[[]*capturedEntries] // Open captures, replaced as a whole, nil when none
+	}
+
+	// capturedEntries accumulates the entries of one CaptureLogs call
+	capturedEntries struct {
+		mu      sync.Mutex
+		entries []observer.LoggedEntry
+	}
+
+	// captureCore is a zapcore.Core recording entries into the open captures before writing them
+	captureCore struct {
+		zapcore.Core
+		context  []zapcore.Field // Fields added through With, recorded with every entry
+		captures *captureSet
+	}
+)
+
+// CaptureLogs returns the entries logged through the manager while fn runs
+//
+// The entries are still written to the configured outputs. Entries logged by other
+// goroutines through the manager, or through loggers derived from it, while fn runs are
+// captured as well, and concurrent or nested calls each capture every entry logged during
+// their own run. Only managers created by New can capture entries; for others fn is run
+// and nil is returned.
+//
+// Parameters:
+//   - fn: The function whose entries are captured
+//
+// Returns:
+//   - []observer.LoggedEntry: The captured entries with their fields, in logging order
+func (m *Manager) CaptureLogs(fn func()) []observer.LoggedEntry {
+	if m.opt == nil {
+		fn()
+		return nil
+	}
+
+	captured := &capturedEntries{}
+	m.opt.captures.add(captured)
+	defer m.opt.captures.remove(captured)
+
+	fn()
+
+	captured.mu.Lock()
+	defer captured.mu.Unlock()
+
+	return captured.entries
+}
+
+// newCaptureCore wraps a core so its entries can be captured by CaptureLogs
+//
+// Parameters:
+//   - core: The core to wrap
+//   - captures: The set of open captures
+//
+// Returns:
+//   - zapcore.Core: A core recording its entries while a capture is open
+func newCaptureCore(core zapcore.Core, captures *captureSet) zapcore.Core {
+	return &captureCore{Core: core, captures: captures}
+}
+
+// With adds structured context to the core, keeping a copy for the captured entries
+func (c *captureCore) With(fields []zapcore.Field) zapcore.Core {
+	context := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	context = append(context, c.context...)
+	context = append(context, fields...)
+
+	return &captureCore{Core: c.Core.With(fields), context: context, captures: c.captures}
+}
+
+// Check delegates to the wrapped core unless a capture is open
+func (c *captureCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.captures.active.Load() == nil {
+		return c.Core.Check(ent, ce)
+	}
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write records the entry into the open captures, then writes it to the wrapped core
+func (c *captureCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if active := c.captures.active.Load(); active != nil {
+		context := make([]zapcore.Field, 0, len(c.context)+len(fields))
+		context = append(context, c.context...)
+		context = append(context, fields...)
+
+		for _, captured := range *active {
+			captured.add(observer.LoggedEntry{Entry: ent, Context: context})
+		}
+	}
+
+	return writeEntry(c.Core, ent, fields)
+}
+
+// add opens a capture
+func (s *captureSet) add(captured *capturedEntries) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var active []*capturedEntries
+	if current := s.active.Load(); current != nil {
+		active = append(active, *current...)
+	}
+	active = append(active, captured)
+	s.active.Store(&active)
+}
+
+// remove closes a capture
+func (s *captureSet) remove(captured *capturedEntries) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := s.active.Load()
+	if current == nil {
+		return
+	}
+
+	var active []*capturedEntries
+	for _, c := range *current {
+		if c != captured {
+			active = append(active, c)
+		}
+	}
+	if len(active) == 0 {
+		s.active.Store(nil)
+		return
+	}
+	s.active.Store(&active)
+}
+
+// add records an entry
+func (c *capturedEntries) add(entry observer.LoggedEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = append(c.entries, entry)
+}
@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestManager_CaptureLogs(t *testing.T) {
+	logger, err := New(WithLevel("info"))
+	require.NoError(t, err)
+	ctx := context.WithValue(context.Background(), TraceIDKey, "trace-1")
+
+	logger.Info(ctx, "before")
+	entries := logger.CaptureLogs(func() {
+		logger.Info(ctx, "inside", zap.Int("step", 1))
+		logger.Log(ctx, DebugLevel, "filtered")
+		logger.With(ctx, zap.String("component", "db")).Warn("derived")
+	})
+	logger.Info(ctx, "after")
+
+	require.Len(t, entries, 2)
+	assert.Equal(t, "inside", entries[0].Message)
+	assert.Equal(t, map[string]interface{}{"TraceID": "trace-1", "step": int64(1)}, entries[0].ContextMap())
+	assert.True(t, entries[0].Caller.Defined)
+	assert.Equal(t, "derived", entries[1].Message)
+	assert.Equal(t, "db", entries[1].ContextMap()["component"])
+}
+
+func TestManager_CaptureLogsConcurrent(t *testing.T) {
+	logger, err := New(WithLevel("info"))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	results := make([]int, 4)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = len(logger.CaptureLogs(func() {
+				for j := 0; j < 10; j++ {
+					logger.Info(context.Background(), "concurrent")
+				}
+			}))
+		}(i)
+	}
+	wg.Wait()
+
+	for _, n := range results {
+		assert.GreaterOrEqual(t, n, 10)
+	}
+	assert.Nil(t, logger.opt.captures.active.Load())
+}
+
+func TestManager_CaptureLogsWithoutNew(t *testing.T) {
+	logger := &Manager{Zap: zap.NewNop()}
+	called := false
+
+	assert.Nil(t, logger.CaptureLogs(func() { called = true }))
+	assert.True(t, called)
+}
@@ -0,0 +1,159 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// strftimeVerb matches strftime conversions in a log file pattern
+	strftimeVerb = regexp.MustCompile(`%[%+A-Za-z]`)
+
+	// strftimeVerbPatterns are the regular expressions of the values of the strftime conversions
+	strftimeVerbPatterns = map[string]string{
+		"%Y": `\d{4}`, "%y": `\d{2}`, "%C": `\d{2}`, "%m": `\d{2}`, "%d": `\d{2}`, "%e": `[ \d]\d`,
+		"%H": `\d{2}`, "%I": `\d{2}`, "%M": `\d{2}`, "%S": `\d{2}`, "%j": `\d{3}`, "%U": `\d{2}`,
+		"%V": `\d{2}`, "%W": `\d{2}`, "%u": `\d`, "%w": `\d`, "%F": `\d{4}-\d{2}-\d{2}`,
+		"%D": `\d{2}/\d{2}/\d{2}`, "%R": `\d{2}:\d{2}`, "%T": `\d{2}:\d{2}:\d{2}`,
+		"%a": `[A-Za-z]{3}`, "%b": `[A-Za-z]{3}`, "%h": `[A-Za-z]{3}`, "%A": `[A-Za-z]+`, "%B": `[A-Za-z]+`,
+		"%p": `[AP]M`, "%%": `%`,
+	}
+)
+
+// cleanupSuffix matches the suffixes of rotated files: a generation number, compression, or both
+const cleanupSuffix = `(\.\d+)?(\.gz)?`
+
+// cleaner periodically removes log files older than maxAge
+//
+// rotatelogs only purges files when it rotates and only matches its own file names, so
+// compressed (.gz) or otherwise suffixed files produced from rotated logs would otherwise
+// accumulate forever.
+type cleaner struct {
+	globs  []string         // Glob patterns of the candidate files, one per log file pattern
+	names  []*regexp.Regexp // Expanded names of the files to clean up, one per glob
+	maxAge time.Duration    // Files last modified before now-maxAge are removed
+	stop   chan struct{}
+	once   sync.Once
+}
+
+// WithCleanupInterval sets how often old log files are scanned and removed
+//
+// The scan removes every file older than maxAge whose name is an expansion of the log file
+// pattern, e.g. "2024-01-02.log", including rotated files suffixed with ".gz", a generation
+// number such as ".1", or both. Other files in the directory are left alone.
+// Only used when driver is "file"; a zero interval disables the scan.
+//
+// Parameters:
+//   - interval: The time between scans
+//
+// Returns:
+//   - Option: A function that sets the cleanup interval in the option struct
+func WithCleanupInterval(interval time.Duration) Option {
+	return func(o *option) {
+		o.cleanupInterval = interval
+	}
+}
+
+// newCleaner creates a cleaner for the given file patterns and starts it
+//
+// Parameters:
+//   - patterns: The strftime patterns of the log files
+//   - maxAge: The maximum age of log files
+//   - interval: The time between scans
+//
+// Returns:
+//   - *cleaner: The running cleaner
+func newCleaner(patterns []string, maxAge, interval time.Duration) *cleaner {
+	c := &cleaner{maxAge: maxAge, stop: make(chan struct{})}
+	for _, pattern := range patterns {
+		// The trailing wildcard matches compression and generation suffixes
+		c.globs = append(c.globs, strftimeVerb.ReplaceAllString(pattern, "*")+"*")
+		c.names = append(c.names, cleanupNames(pattern))
+	}
+
+	go c.run(interval)
+
+	return c
+}
+
+// run scans for old files every interval until the cleaner is closed
+func (c *cleaner) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		c.clean(time.Now())
+
+		select {
+		case <-ticker.C:
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// clean removes the files older than maxAge relative to now
+func (c *cleaner) clean(now time.Time) {
+	cutoff := now.Add(-c.maxAge)
+	for i, glob := range c.globs {
+		matches, err := filepath.Glob(glob)
+		if err != nil {
+			continue
+		}
+
+		for _, path := range matches {
+			// Only the log files and their rotated copies, not rotatelogs' lock and symlink files
+			if !c.names[i].MatchString(filepath.ToSlash(path)) {
+				continue
+			}
+
+			fi, err := os.Stat(path)
+			if err != nil || fi.IsDir() || fi.ModTime().After(cutoff) {
+				continue
+			}
+			_ = os.Remove(path)
+		}
+	}
+}
+
+// cleanupNames returns the regular expression of the file names a strftime pattern expands to
+//
+// Date and time conversions match their fixed-width digits, so files that merely share the
+// shape of the pattern, e.g. "a-b-c.log" for "%Y-%m-%d.log", are not matched. Conversions
+// without a known form match any name segment.
+//
+// Parameters:
+//   - pattern: The strftime pattern of the log files
+//
+// Returns:
+//   - *regexp.Regexp: The expression matching the slash-separated paths of the files
+func cleanupNames(pattern string) *regexp.Regexp {
+	pattern = filepath.ToSlash(pattern)
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	last := 0
+	for _, loc := range strftimeVerb.FindAllStringIndex(pattern, -1) {
+		expr.WriteString(regexp.QuoteMeta(pattern[last:loc[0]]))
+		verb, ok := strftimeVerbPatterns[pattern[loc[0]:loc[1]]]
+		if !ok {
+			verb = `[^/]+`
+		}
+		expr.WriteString(verb)
+		last = loc[1]
+	}
+	expr.WriteString(regexp.QuoteMeta(pattern[last:]))
+	expr.WriteString(cleanupSuffix + "$")
+
+	return regexp.MustCompile(expr.String())
+}
+
+// Close stops the cleaner
+func (c *cleaner) Close() error {
+	c.once.Do(func() { close(c.stop) })
+	return nil
+}
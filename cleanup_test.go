@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleaner_RemovesOldCompressedFiles(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	old := now.Add(-10 * 24 * time.Hour)
+
+	files := map[string]time.Time{
+		"2024-01-01.log.gz":     old,
+		"2024-01-01.log.1":      old,
+		"2024-01-02.log":        old,
+		"2024-01-09.log.gz":     now,
+		"2024-01-09.log":        now,
+		"2024-01-01.log.1.gz":   old,
+		"unrelated.txt":         old,
+		"a-b-c.log":             old,
+		"release-notes-v1.log":  old,
+		"my-backup-2020.log.gz": old,
+		"2024-01-01.log.bak":    old,
+	}
+	for name, mtime := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte("entry\n"), 0644))
+		require.NoError(t, os.Chtimes(path, mtime, mtime))
+	}
+
+	c := newCleaner(logFilePatterns(&option{logPath: dir + "/"}), 7*24*time.Hour, time.Hour)
+	defer c.Close()
+	c.clean(now)
+
+	for name, mtime := range files {
+		_, err := os.Stat(filepath.Join(dir, name))
+		if mtime.Equal(old) && strings.HasPrefix(name, "2024-") && name != "2024-01-01.log.bak" {
+			assert.True(t, os.IsNotExist(err), name)
+		} else {
+			assert.NoError(t, err, name)
+		}
+	}
+}
+
+func TestCleanupNames(t *testing.T) {
+	names := cleanupNames(filepath.Join("logs", "%Y-%m-%d", "error.log"))
+
+	assert.True(t, names.MatchString("logs/2024-01-02/error.log"))
+	assert.True(t, names.MatchString("logs/2024-01-02/error.log.3.gz"))
+	assert.False(t, names.MatchString("logs/a-b-c/error.log"))
+	assert.False(t, names.MatchString("logs/2024-01-02/error.log_lock"))
+	assert.False(t, names.MatchString("logs/2024-01-02/error.log.old"))
+	assert.False(t, names.MatchString("other/2024-01-02/error.log"))
+}
@@ -0,0 +1,85 @@
+// Command logdecrypt prints the entries of log files encrypted with logger.WithEncryption
+//
+// Usage:
+//
+//	logdecrypt -key-file key.hex 2024-01-02.log [more files...]
+//
+// The key file holds the AES key in hex; LOG_ENCRYPTION_KEY is read instead when no key
+// file is given. Without file arguments the encrypted log is read from stdin.
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/sk-pkg/logger"
+)
+
+func main() {
+	keyFile := flag.String("key-file", "", "file holding the hex-encoded AES key, LOG_ENCRYPTION_KEY when empty")
+	flag.Parse()
+
+	if err := run(*keyFile, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "logdecrypt:", err)
+		os.Exit(1)
+	}
+}
+
+// run decrypts the files, or stdin when there are none, to stdout
+func run(keyFile string, files []string) error {
+	key, err := readKey(keyFile)
+	if err != nil {
+		return err
+	}
+
+	if len(files) == 0 {
+		return decrypt(os.Stdin, key)
+	}
+
+	for _, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		err = decrypt(f, key)
+		_ = f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// readKey returns the key of the key file, or of LOG_ENCRYPTION_KEY when keyFile is empty
+func readKey(keyFile string) ([]byte, error) {
+	encoded := os.Getenv("LOG_ENCRYPTION_KEY")
+	if keyFile != "" {
+		content, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		encoded = string(content)
+	}
+	if encoded == "" {
+		return nil, fmt.Errorf("no key: set -key-file or LOG_ENCRYPTION_KEY")
+	}
+
+	return hex.DecodeString(strings.TrimSpace(encoded))
+}
+
+// decrypt writes the plaintext entries of an encrypted log to stdout
+func decrypt(r io.Reader, key []byte) error {
+	plaintext, err := logger.NewDecryptReader(r, key)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(os.Stdout, plaintext)
+
+	return err
+}
@@ -0,0 +1,177 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"strings"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// ANSI escape sequences used to style fields
+const (
+	ansiReset = "\x1b[0m"
+	ansiDim   = "\x1b[2m"
+	ansiRed   = "\x1b[31m"
+)
+
+// colorPool is the buffer pool used by the field color encoder
+var colorPool = buffer.NewPool()
+
+// stdoutIsTerminal reports whether stdout is attached to a terminal
+var stdoutIsTerminal = func() bool {
+	fi, err := os.Stdout.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// fieldColorEncoder is a console encoder that dims field keys and highlights error values
+type fieldColorEncoder struct {
+	zapcore.Encoder
+}
+
+// WithFieldColors styles the fields of console output with ANSI colors
+//
+// Field keys are dimmed and the values of error fields are shown in red. It only applies
+// to the console encoding on stdout, and is disabled when stdout is not a terminal or the
+// NO_COLOR environment variable is set.
+//
+// Parameters:
+//   - enabled: Whether fields are colored
+//
+// Returns:
+//   - Option: A function that sets the field colors in the option struct
+func WithFieldColors(enabled bool) Option {
+	return func(o *option) {
+		o.fieldColors = enabled
+	}
+}
+
+// fieldColorsEnabled reports whether field colors apply to the resolved options
+func fieldColorsEnabled(opt *option) bool {
+	if !opt.fieldColors || opt.encoding != "console" || opt.driver != "stdout" {
+		return false
+	}
+
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+
+	return stdoutIsTerminal()
+}
+
+// newFieldColorEncoder wraps a console encoder to color its fields
+//
+// Parameters:
+//   - enc: The console encoder to wrap
+//
+// Returns:
+//   - zapcore.Encoder: An encoder coloring field keys and error values
+func newFieldColorEncoder(enc zapcore.Encoder) zapcore.Encoder {
+	return &fieldColorEncoder{Encoder: enc}
+}
+
+// Clone implements zapcore.Encoder
+func (e *fieldColorEncoder) Clone() zapcore.Encoder {
+	return &fieldColorEncoder{Encoder: e.Encoder.Clone()}
+}
+
+// EncodeEntry implements zapcore.Encoder
+func (e *fieldColorEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	line, err := e.Encoder.EncodeEntry(ent, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	// The console encoder writes the fields as a JSON object after the last tab
+	raw := line.Bytes()
+	start := bytes.LastIndex(raw, []byte("\t{"))
+	if start < 0 {
+		return line, nil
+	}
+
+	out := colorPool.Get()
+	_, _ = out.Write(raw[:start+1])
+	colorJSONFields(out, raw[start+1:])
+	line.Free()
+
+	return out, nil
+}
+
+// colorJSONFields writes a JSON object, dimming its keys and coloring error values red
+func colorJSONFields(out *buffer.Buffer, data []byte) {
+	depth := 0
+	redDepth := -1 // Depth of the error value being colored, -1 when none
+	var lastKey string
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch c {
+		case '"':
+			end := stringEnd(data, i)
+			str := data[i : end+1]
+			i = end
+
+			if next := nextNonSpace(data, end+1); next < len(data) && data[next] == ':' && redDepth < 0 {
+				lastKey = string(str[1 : len(str)-1])
+				out.AppendString(ansiDim)
+				_, _ = out.Write(str)
+				out.AppendString(ansiReset)
+				continue
+			}
+
+			if redDepth < 0 && isErrorKey(lastKey) {
+				out.AppendString(ansiRed)
+				_, _ = out.Write(str)
+				out.AppendString(ansiReset)
+				continue
+			}
+			_, _ = out.Write(str)
+		case '{', '[':
+			if redDepth < 0 && isErrorKey(lastKey) {
+				redDepth = depth
+				out.AppendString(ansiRed)
+			}
+			depth++
+			out.AppendByte(c)
+		case '}', ']':
+			depth--
+			out.AppendByte(c)
+			if depth == redDepth {
+				out.AppendString(ansiReset)
+				redDepth = -1
+			}
+		case ',':
+			lastKey = ""
+			out.AppendByte(c)
+		default:
+			out.AppendByte(c)
+		}
+	}
+}
+
+// isErrorKey reports whether a field key holds an error
+func isErrorKey(key string) bool {
+	return key == "error" || key == "errorVerbose" || strings.HasSuffix(key, "Error") || strings.HasSuffix(key, "_error")
+}
+
+// stringEnd returns the index of the closing quote of the JSON string starting at start
+func stringEnd(data []byte, start int) int {
+	for i := start + 1; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return len(data) - 1
+}
+
+// nextNonSpace returns the index of the next non-whitespace byte at or after i
+func nextNonSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\n') {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,44 @@
+package logger
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestFieldColorEncoder(t *testing.T) {
+	enc := newFieldColorEncoder(zapcore.NewConsoleEncoder(DefaultEncoderConfig))
+
+	buf, err := enc.EncodeEntry(zapcore.Entry{Message: "request failed"}, []zapcore.Field{
+		zap.String("path", "/users"),
+		zap.Error(errors.New("connection refused")),
+	})
+	require.NoError(t, err)
+	line := buf.String()
+
+	assert.Contains(t, line, ansiDim+`"path"`+ansiReset)
+	assert.Contains(t, line, ansiDim+`"error"`+ansiReset)
+	assert.Contains(t, line, ansiRed+`"connection refused"`+ansiReset)
+	assert.NotContains(t, line, ansiRed+`"/users"`)
+	assert.True(t, strings.HasSuffix(line, "}\n"))
+}
+
+func TestFieldColorsEnabled(t *testing.T) {
+	terminal := stdoutIsTerminal
+	defer func() { stdoutIsTerminal = terminal }()
+	stdoutIsTerminal = func() bool { return true }
+
+	opt := &option{driver: "stdout", encoding: "console", fieldColors: true}
+	assert.True(t, fieldColorsEnabled(opt))
+
+	t.Setenv("NO_COLOR", "1")
+	assert.False(t, fieldColorsEnabled(opt))
+
+	stdoutIsTerminal = func() bool { return false }
+	assert.False(t, fieldColorsEnabled(&option{driver: "stdout", encoding: "json", fieldColors: true}))
+}
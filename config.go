@@ -0,0 +1,119 @@
+package logger
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	// Config is a JSON-serializable view of a Manager's effective configuration
+	Config struct {
+		Driver          string          `json:"driver"`
+		Level           string          `json:"level"`
+		LogPath         string          `json:"log_path"`
+		SplitByLevelDir string          `json:"split_by_level_dir,omitempty"`
+		Encoding        string          `json:"encoding"`
+		UseColor        bool            `json:"use_color"`
+		CallerSkip      int             `json:"caller_skip"`
+		MaxAge          time.Duration   `json:"max_age"`
+		RotationTime    time.Duration   `json:"rotation_time"`
+		StacktraceLevel string          `json:"stacktrace_level"`
+		TimeFormat      string          `json:"time_format,omitempty"`
+		Timezone        string          `json:"timezone,omitempty"`
+		EncoderKeys     EncoderKeys     `json:"encoder_keys"`
+		CallerSampling  *SamplingConfig `json:"caller_sampling,omitempty"`
+	}
+
+	// EncoderKeys lists the keys used by the encoder, an empty key omits the value
+	EncoderKeys struct {
+		Time       string `json:"time"`
+		Level      string `json:"level"`
+		Name       string `json:"name"`
+		Message    string `json:"message"`
+		Caller     string `json:"caller"`
+		Function   string `json:"function"`
+		Stacktrace string `json:"stacktrace"`
+	}
+
+	// SamplingConfig describes a sampling policy
+	SamplingConfig struct {
+		Initial    int           `json:"initial"`
+		Thereafter int           `json:"thereafter"`
+		Tick       time.Duration `json:"tick"`
+	}
+)
+
+// Config returns the effective configuration of the manager
+//
+// Level and CallerSkip reflect the current values, including changes made through
+// SetLevel and SetCallerSkip after creation.
+//
+// Returns:
+//   - Config: The effective configuration, zero if the manager was not created by New
+func (m *Manager) Config() Config {
+	opt := m.options()
+	if opt == nil {
+		return Config{}
+	}
+
+	return newConfig(opt, m.level.Level(), m.callerSkip.Load())
+}
+
+// DefaultOptions returns the configuration New applies when no option is given
+//
+// Returns:
+//   - Config: The default configuration
+func DefaultOptions() Config {
+	opt := newOptions()
+
+	return newConfig(opt, opt.level, opt.callerSkip)
+}
+
+// newConfig creates the Config view of options
+//
+// Parameters:
+//   - opt: The option struct containing configuration
+//   - level: The current level
+//   - callerSkip: The current caller skip
+//
+// Returns:
+//   - Config: The configuration described by the options
+func newConfig(opt *option, level zapcore.Level, callerSkip int) Config {
+	config := Config{
+		Driver:          opt.driver,
+		Level:           levelName(level),
+		LogPath:         opt.logPath,
+		SplitByLevelDir: opt.splitByLevelDir,
+		Encoding:        opt.encoding,
+		UseColor:        opt.useColor,
+		CallerSkip:      callerSkip,
+		MaxAge:          opt.maxAge,
+		RotationTime:    opt.rotationTime,
+		StacktraceLevel: levelName(opt.stacktraceLevel),
+		TimeFormat:      opt.timeFormat,
+		EncoderKeys: EncoderKeys{
+			Time:       opt.encoderConfig.TimeKey,
+			Level:      opt.encoderConfig.LevelKey,
+			Name:       opt.encoderConfig.NameKey,
+			Message:    opt.encoderConfig.MessageKey,
+			Caller:     opt.encoderConfig.CallerKey,
+			Function:   opt.encoderConfig.FunctionKey,
+			Stacktrace: opt.encoderConfig.StacktraceKey,
+		},
+	}
+
+	if opt.timezone != nil {
+		config.Timezone = opt.timezone.String()
+	}
+
+	if opt.callerSampling != nil {
+		config.CallerSampling = &SamplingConfig{
+			Initial:    opt.callerSampling.initial,
+			Thereafter: opt.callerSampling.thereafter,
+			Tick:       opt.callerSampling.tick,
+		}
+	}
+
+	return config
+}
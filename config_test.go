@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestManager_Config(t *testing.T) {
+	logger, err := New(
+		WithLevel("warn"),
+		WithColor(true),
+		WithMaxAge(48*time.Hour),
+		WithRotationTime(time.Hour),
+		WithStacktraceLevel("error"),
+		WithUTC(),
+		WithCallerSampling(10, 5, time.Second),
+	)
+	require.NoError(t, err)
+
+	config := logger.Config()
+	assert.Equal(t, "stdout", config.Driver)
+	assert.Equal(t, "warn", config.Level)
+	assert.Equal(t, "console", config.Encoding)
+	assert.True(t, config.UseColor)
+	assert.Equal(t, defaultCallerSkip, config.CallerSkip)
+	assert.Equal(t, 48*time.Hour, config.MaxAge)
+	assert.Equal(t, time.Hour, config.RotationTime)
+	assert.Equal(t, "error", config.StacktraceLevel)
+	assert.Equal(t, "UTC", config.Timezone)
+	assert.Equal(t, "M", config.EncoderKeys.Message)
+	assert.Equal(t, &SamplingConfig{Initial: 10, Thereafter: 5, Tick: time.Second}, config.CallerSampling)
+
+	logger.SetLevel(zapcore.DebugLevel)
+	assert.Equal(t, "debug", logger.Config().Level)
+
+	_, err = json.Marshal(config)
+	assert.NoError(t, err)
+}
+
+func TestDefaultOptions(t *testing.T) {
+	logger, err := New()
+	require.NoError(t, err)
+
+	assert.Equal(t, logger.Config(), DefaultOptions())
+}
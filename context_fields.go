@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+// contextField extracts a registered value from the context
+type contextField struct {
+	key     any
+	name    string
+	extract func(ctx context.Context) (zap.Field, bool)
+}
+
+var (
+	// contextFields holds the registered context fields, replaced as a whole on registration
+	contextFields atomic.Pointer[[]contextField]
+
+	// contextFieldsMu serializes registrations
+	contextFieldsMu sync.Mutex
+)
+
+// RegisterContextField logs the context value stored under key as the field fieldName
+//
+// Every log call whose context holds a value of type T under key gets a string field
+// rendered by render; values of another type are ignored. This replaces the type
+// assertions an untyped string key such as TraceIDKey requires. Registering the same key
+// again replaces its registration. key must be comparable, like with context.WithValue.
+// Registrations are process-wide and should happen during initialization.
+//
+// Parameters:
+//   - key: The context key of the value
+//   - fieldName: The key of the logged field
+//   - render: The function rendering the value as a string
+func RegisterContextField[T any](key any, fieldName string, render func(T) string) {
+	field := contextField{
+		key:  key,
+		name: fieldName,
+		extract: func(ctx context.Context) (zap.Field, bool) {
+			value, ok := ctx.Value(key).(T)
+			if !ok {
+				return zap.Skip(), false
+			}
+			return zap.String(fieldName, render(value)), true
+		},
+	}
+
+	contextFieldsMu.Lock()
+	defer contextFieldsMu.Unlock()
+
+	var registered []contextField
+	if current := contextFields.Load(); current != nil {
+		registered = make([]contextField, 0, len(*current)+1)
+		for _, f := range *current {
+			if f.key != key {
+				registered = append(registered, f)
+			}
+		}
+	}
+	registered = append(registered, field)
+	contextFields.Store(&registered)
+}
+
+// getRegisteredContextFields returns the fields of the registered context values found in ctx
+//
+// Parameters:
+//   - ctx: The context.Context to extract the values from
+//   - fields: The fields passed at the call site, whose keys are not extracted again
+//
+// Returns:
+//   - []zap.Field: The extracted fields
+func getRegisteredContextFields(ctx context.Context, fields []zap.Field) []zap.Field {
+	registered := contextFields.Load()
+	if registered == nil {
+		return nil
+	}
+
+	var extracted []zap.Field
+	for _, f := range *registered {
+		if hasFieldKey(fields, f.name) {
+			continue
+		}
+		if field, ok := f.extract(ctx); ok {
+			extracted = append(extracted, field)
+		}
+	}
+
+	return extracted
+}
+
+// hasFieldKey reports whether one of the fields has the given key
+func hasFieldKey(fields []zap.Field, key string) bool {
+	for _, f := range fields {
+		if f.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
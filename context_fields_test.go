@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type (
+	tenantKey struct{}
+	userKey   struct{}
+
+	user struct {
+		id   int
+		name string
+	}
+)
+
+func TestRegisterContextField(t *testing.T) {
+	RegisterContextField(tenantKey{}, "tenant_id", strconv.Itoa)
+	RegisterContextField(userKey{}, "user", func(u user) string { return u.name + "#" + strconv.Itoa(u.id) })
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		fields []zap.Field
+		want   map[string]interface{}
+	}{
+		{
+			name: "int value",
+			ctx:  context.WithValue(context.Background(), tenantKey{}, 42),
+			want: map[string]interface{}{"tenant_id": "42"},
+		},
+		{
+			name: "struct value",
+			ctx:  context.WithValue(context.Background(), userKey{}, user{id: 7, name: "ada"}),
+			want: map[string]interface{}{"user": "ada#7"},
+		},
+		{
+			name: "value of another type is ignored",
+			ctx:  context.WithValue(context.Background(), tenantKey{}, "42"),
+			want: map[string]interface{}{},
+		},
+		{
+			name:   "call-site field wins",
+			ctx:    context.WithValue(context.Background(), tenantKey{}, 42),
+			fields: []zap.Field{zap.String("tenant_id", "override")},
+			want:   map[string]interface{}{"tenant_id": "override"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, recorded := observer.New(zapcore.InfoLevel)
+			logger := &Manager{Zap: zap.New(core)}
+			logger.Info(tt.ctx, "request", tt.fields...)
+
+			require.Equal(t, 1, recorded.Len())
+			assert.Equal(t, tt.want, recorded.All()[0].ContextMap())
+		})
+	}
+}
+
+func TestRegisterContextField_Replace(t *testing.T) {
+	type key struct{}
+	RegisterContextField(key{}, "first", strconv.Itoa)
+	RegisterContextField(key{}, "second", strconv.Itoa)
+
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger := &Manager{Zap: zap.New(core)}
+	logger.Info(context.WithValue(context.Background(), key{}, 1), "request")
+
+	require.Equal(t, 1, recorded.Len())
+	assert.Equal(t, map[string]interface{}{"second": "1"}, recorded.All()[0].ContextMap())
+}
@@ -0,0 +1,208 @@
+package logger
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	// checkedWriter is implemented by the cores that check and write an entry in one call
+	//
+	// writeEntry calls it instead of going through a zapcore.CheckedEntry, which only reports
+	// the write errors as text, so the errors reach the caller unchanged.
+	checkedWriter interface {
+		// checkWrite writes the entry if the core's Check would have accepted it
+		checkWrite(ent zapcore.Entry, fields []zapcore.Field) error
+	}
+
+	// levelCheckedCore is a zapcore.Core whose Check only adds the core itself once its level is enabled
+	levelCheckedCore interface {
+		zapcore.Core
+		checkedByLevel()
+	}
+
+	// levelChecked is embedded by the cores whose Check only adds them once their level is enabled
+	levelChecked struct{}
+
+	// directCore wraps a core checked by level only, such as the ones of zapcore.NewCore
+	directCore struct {
+		zapcore.Core
+		levelChecked
+	}
+
+	// teeCore is a zapcore.Core duplicating entries to several cores, like zapcore.NewTee
+	teeCore []zapcore.Core
+
+	// writeErrors is a zapcore.WriteSyncer collecting the lines a CheckedEntry reports
+	writeErrors struct {
+		err error
+	}
+)
+
+// writeErrorsPool recycles the collectors of writeEntry
+var writeErrorsPool = sync.Pool{New: func() interface{} { return &writeErrors{} }}
+
+// writeEntry writes an entry through the given core, honoring the core's own level checks
+//
+// Core wrappers that need the fully populated entry (e.g. its Caller) can only act in Write,
+// because zap resolves the caller after Check. Re-checking the wrapped core here keeps
+// level-filtered tees and nested wrappers working as if they had been checked directly.
+// The cores of this package are written directly, so their write errors are returned
+// unchanged and errors.Is and errors.As work on them. Other cores, e.g. the ones passed to
+// WithExtraCore, are written through a zapcore.CheckedEntry, which only reports their errors
+// as a "<time> write error: <error>" line; the line is returned as the error's message.
+//
+// Parameters:
+//   - core: The core to write through
+//   - ent: The entry to write
+//   - fields: The fields to write with the entry
+//
+// Returns:
+//   - error: The errors of the wrapped core's writes, nil if every write succeeded
+func writeEntry(core zapcore.Core, ent zapcore.Entry, fields []zapcore.Field) error {
+	switch c := core.(type) {
+	case checkedWriter:
+		return c.checkWrite(ent, fields)
+	case levelCheckedCore:
+		if !c.Enabled(ent.Level) {
+			return nil
+		}
+		return c.Write(ent, fields)
+	}
+
+	ce := core.Check(ent, nil)
+	if ce == nil {
+		return nil
+	}
+
+	collector := writeErrorsPool.Get().(*writeErrors)
+	ce.ErrorOutput = collector
+	ce.Write(fields...)
+
+	err := collector.err
+	collector.err = nil
+	writeErrorsPool.Put(collector)
+
+	return err
+}
+
+// checkedByLevel marks the core as checked by level only
+func (levelChecked) checkedByLevel() {}
+
+// newDirectCore wraps a core whose Check only adds it once its level is enabled, so writeEntry writes to it directly
+//
+// Parameters:
+//   - core: The core to wrap, e.g. created by zapcore.NewCore
+//
+// Returns:
+//   - zapcore.Core: A core returning the write errors of the wrapped core unchanged to writeEntry
+func newDirectCore(core zapcore.Core) zapcore.Core {
+	return &directCore{Core: core}
+}
+
+// With adds structured context to the wrapped core
+func (c *directCore) With(fields []zapcore.Field) zapcore.Core {
+	return &directCore{Core: c.Core.With(fields)}
+}
+
+// Check adds the core if the entry's level is enabled
+func (c *directCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// newTeeCore creates a core duplicating entries to the given cores
+//
+// Parameters:
+//   - cores: The cores receiving every entry they are enabled for
+//
+// Returns:
+//   - zapcore.Core: The only core if there is one, a tee otherwise
+func newTeeCore(cores ...zapcore.Core) zapcore.Core {
+	switch len(cores) {
+	case 0:
+		return zapcore.NewNopCore()
+	case 1:
+		return cores[0]
+	}
+
+	return teeCore(cores)
+}
+
+// Enabled reports whether any of the cores is enabled for the level
+func (t teeCore) Enabled(level zapcore.Level) bool {
+	for _, core := range t {
+		if core.Enabled(level) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// With adds structured context to every core
+func (t teeCore) With(fields []zapcore.Field) zapcore.Core {
+	cores := make(teeCore, len(t))
+	for i, core := range t {
+		cores[i] = core.With(fields)
+	}
+
+	return cores
+}
+
+// Check lets every core add itself to the checked entry
+func (t teeCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	for _, core := range t {
+		ce = core.Check(ent, ce)
+	}
+
+	return ce
+}
+
+// Write writes the entry to every core, whatever their level, like zapcore.NewTee
+func (t teeCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	var err error
+	for _, core := range t {
+		err = errors.Join(err, core.Write(ent, fields))
+	}
+
+	return err
+}
+
+// checkWrite implements checkedWriter, writing to the cores that accept the entry
+func (t teeCore) checkWrite(ent zapcore.Entry, fields []zapcore.Field) error {
+	var err error
+	for _, core := range t {
+		err = errors.Join(err, writeEntry(core, ent, fields))
+	}
+
+	return err
+}
+
+// Sync flushes every core
+func (t teeCore) Sync() error {
+	var err error
+	for _, core := range t {
+		err = errors.Join(err, core.Sync())
+	}
+
+	return err
+}
+
+// Write implements zapcore.WriteSyncer, recording a reported line as an error
+func (w *writeErrors) Write(p []byte) (int, error) {
+	w.err = errors.Join(w.err, errors.New(strings.TrimSuffix(string(p), "\n")))
+
+	return len(p), nil
+}
+
+// Sync implements zapcore.WriteSyncer, there is nothing to flush
+func (w *writeErrors) Sync() error {
+	return nil
+}
@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWriteEntry_Error(t *testing.T) {
+	errDiskFull := errors.New("disk full")
+	failing := zapcore.NewCore(zapcore.NewJSONEncoder(DefaultEncoderConfig), zapcore.AddSync(failingWriter{err: errDiskFull}), zapcore.DebugLevel)
+	errorsOnly, recorded := observer.New(zapcore.ErrorLevel)
+	core := newMessagePrefixCore(newSequenceCore(newTeeCore(newDirectCore(failing), newDirectCore(errorsOnly))), "[orders] ")
+
+	err := writeEntry(core, zapcore.Entry{Level: zapcore.InfoLevel, Message: "order created"}, nil)
+	assert.ErrorIs(t, err, errDiskFull)
+	assert.EqualError(t, err, "disk full")
+	assert.Zero(t, recorded.Len(), "the tee still checks the level of each core")
+
+	assert.NoError(t, writeEntry(zapcore.NewNopCore(), zapcore.Entry{Level: zapcore.InfoLevel}, nil))
+}
+
+func TestWriteEntry_OtherCore(t *testing.T) {
+	failing := zapcore.NewCore(zapcore.NewJSONEncoder(DefaultEncoderConfig), zapcore.AddSync(failingWriter{err: errors.New("disk full")}), zapcore.DebugLevel)
+
+	// Cores of other packages are written through a CheckedEntry, which reports the error as
+	// a line in this format with the zap version in go.mod
+	err := writeEntry(failing, zapcore.Entry{Level: zapcore.InfoLevel, Message: "order created"}, nil)
+	assert.EqualError(t, err, "0001-01-01 00:00:00 +0000 UTC write error: disk full")
+
+	err = writeEntry(failing, zapcore.Entry{Level: zapcore.InfoLevel, Message: "order created"}, nil)
+	assert.EqualError(t, err, "0001-01-01 00:00:00 +0000 UTC write error: disk full", "the collector is reset between writes")
+}
+
+func TestWriteEntry_ErrorOutput(t *testing.T) {
+	core, _, err := BuildCore(
+		WithSinkEncoded("json", false, failingWriter{err: errors.New("disk full")}, zapcore.DebugLevel),
+		WithMessagePrefix("[orders] "),
+		WithSequence(),
+	)
+	require.NoError(t, err)
+
+	var errorOutput bytes.Buffer
+	logger := zap.New(core, zap.ErrorOutput(zapcore.AddSync(&errorOutput)))
+	logger.Info("order created")
+
+	assert.Regexp(t, `^[^\n]+ write error: disk full\n$`, errorOutput.String())
+	assert.Equal(t, 1, strings.Count(errorOutput.String(), "write error"), "the error is reported once, as returned by the output")
+}
@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// WithContextDeadline logs the time left before the context's deadline with every entry
+//
+// When the context passed to a log call has a deadline, the entry gets a
+// "deadline_remaining_ms" field, negative once the deadline has passed, and a
+// "ctx_cancelled" field telling whether the context is done. Both are computed at log
+// time, which makes timeouts easy to spot. Contexts without a deadline add no fields.
+//
+// Returns:
+//   - Option: A function that enables the deadline fields in the option struct
+func WithContextDeadline() Option {
+	return func(o *option) {
+		o.contextDeadline = true
+	}
+}
+
+// getDeadlineFields returns the deadline fields of the context
+//
+// Parameters:
+//   - ctx: The context.Context of the log call
+//
+// Returns:
+//   - []zap.Field: The remaining time and cancellation fields, nil without a deadline
+func getDeadlineFields(ctx context.Context) []zap.Field {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil
+	}
+
+	return []zap.Field{
+		zap.Int64("deadline_remaining_ms", time.Until(deadline).Milliseconds()),
+		zap.Bool("ctx_cancelled", ctx.Err() != nil),
+	}
+}
@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithContextDeadline(t *testing.T) {
+	opt := &option{}
+	WithContextDeadline()(opt)
+
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger := &Manager{Zap: zap.New(core), opt: opt}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	logger.Info(ctx, "pending")
+	cancel()
+	logger.Info(ctx, "cancelled")
+	logger.Info(context.Background(), "no deadline")
+
+	require.Equal(t, 3, recorded.Len())
+	entries := recorded.All()
+
+	remaining := entries[0].ContextMap()["deadline_remaining_ms"].(int64)
+	assert.InDelta(t, time.Minute.Milliseconds(), remaining, 1000)
+	assert.Equal(t, false, entries[0].ContextMap()["ctx_cancelled"])
+
+	assert.Equal(t, true, entries[1].ContextMap()["ctx_cancelled"])
+	assert.Empty(t, entries[2].ContextMap())
+}
+
+func TestWithContextDeadline_Disabled(t *testing.T) {
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger := &Manager{Zap: zap.New(core), opt: &option{}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	logger.Info(ctx, "pending")
+
+	require.Equal(t, 1, recorded.Len())
+	assert.Empty(t, recorded.All()[0].ContextMap())
+}
@@ -0,0 +1,36 @@
+//go:build !nodebug
+
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// debugCompiled reports whether Debug and Trace log, false in builds with the nodebug tag
+const debugCompiled = true
+
+// Trace logs a message at TraceLevel
+//
+// Trace entries are only written when the level is set to TraceLevel (WithLevel("trace")).
+//
+// Parameters:
+//   - ctx: The context.Context for this log entry
+//   - msg: The message to log
+//   - fields: Optional fields to add to the log entry
+func (m *Manager) Trace(ctx context.Context, msg string, fields ...zap.Field) {
+	logger := m.getLoggerWithTraceID(ctx, fields...)
+	logger.Log(TraceLevel, msg, fields...)
+}
+
+// Debug logs a message at DebugLevel
+//
+// Parameters:
+//   - ctx: The context.Context for this log entry
+//   - msg: The message to log
+//   - fields: Optional fields to add to the log entry
+func (m *Manager) Debug(ctx context.Context, msg string, fields ...zap.Field) {
+	logger := m.getLoggerWithTraceID(ctx, fields...)
+	logger.Debug(msg, fields...)
+}
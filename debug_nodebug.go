@@ -0,0 +1,32 @@
+//go:build nodebug
+
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// debugCompiled reports whether Debug and Trace log, false in builds with the nodebug tag
+const debugCompiled = false
+
+// Trace does nothing in builds with the nodebug tag
+//
+// Parameters:
+//   - ctx: The context.Context for this log entry
+//   - msg: The message to log
+//   - fields: Optional fields to add to the log entry
+func (m *Manager) Trace(ctx context.Context, msg string, fields ...zap.Field) {}
+
+// Debug does nothing in builds with the nodebug tag
+//
+// The empty body is inlined, so the call and the level check disappear from the binary.
+// Arguments without side effects are eliminated as well; arguments that call functions
+// are still evaluated, as for any Go call.
+//
+// Parameters:
+//   - ctx: The context.Context for this log entry
+//   - msg: The message to log
+//   - fields: Optional fields to add to the log entry
+func (m *Manager) Debug(ctx context.Context, msg string, fields ...zap.Field) {}
@@ -0,0 +1,26 @@
+//go:build nodebug
+
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestDebug_NoDebugTag(t *testing.T) {
+	core, recorded := observer.New(TraceLevel)
+	logger := &Manager{Zap: zap.New(core)}
+
+	logger.Trace(context.Background(), "trace")
+	logger.Debug(context.Background(), "debug", zap.String("key", "value"))
+	logger.Info(context.Background(), "info")
+
+	if assert.Equal(t, 1, recorded.Len()) {
+		assert.Equal(t, zapcore.InfoLevel, recorded.All()[0].Level)
+	}
+}
@@ -0,0 +1,117 @@
+package logger
+
+import (
+	"strconv"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// dedupeCore is a zapcore.Core that collapses fields sharing a key
+//
+// Context fields added through With are kept on the core instead of being encoded upfront,
+// so they can be deduplicated against the fields passed at the call site.
+type dedupeCore struct {
+	zapcore.Core
+	levelChecked
+	keepLast bool            // Whether the last occurrence of a key wins
+	context  []zapcore.Field // Fields added through With
+}
+
+// WithDedupeKeys collapses fields sharing the same key into a single field
+//
+// This covers a call-site field overriding a field of a With-derived logger as well as the
+// same key passed twice. Keys are compared within their namespace.
+//
+// Parameters:
+//   - keepLast: Whether the last occurrence of a key is kept instead of the first
+//
+// Returns:
+//   - Option: A function that enables key de-duplication in the option struct
+func WithDedupeKeys(keepLast bool) Option {
+	return func(o *option) {
+		o.dedupeKeys = true
+		o.dedupeKeepLast = keepLast
+	}
+}
+
+// newDedupeCore wraps a core to collapse duplicate keys
+//
+// Parameters:
+//   - core: The core to wrap
+//   - keepLast: Whether the last occurrence of a key is kept instead of the first
+//
+// Returns:
+//   - zapcore.Core: A core writing each key at most once
+func newDedupeCore(core zapcore.Core, keepLast bool) zapcore.Core {
+	return &dedupeCore{Core: core, keepLast: keepLast}
+}
+
+// With keeps the fields on the core so they can be deduplicated at write time
+func (c *dedupeCore) With(fields []zapcore.Field) zapcore.Core {
+	context := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	context = append(context, c.context...)
+	context = append(context, fields...)
+
+	return &dedupeCore{Core: c.Core, keepLast: c.keepLast, context: context}
+}
+
+// Check adds the core so Write can merge the context and call-site fields
+func (c *dedupeCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write writes the entry with duplicate keys removed
+func (c *dedupeCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	all := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	all = append(all, c.context...)
+	all = append(all, fields...)
+
+	return writeEntry(c.Core, ent, dedupeFields(all, c.keepLast))
+}
+
+// dedupeFields removes the fields whose key already appears in the same namespace
+//
+// Parameters:
+//   - fields: The fields in encoding order
+//   - keepLast: Whether the last occurrence of a key is kept instead of the first
+//
+// Returns:
+//   - []zapcore.Field: The fields with unique keys, in their original order
+func dedupeFields(fields []zapcore.Field, keepLast bool) []zapcore.Field {
+	// Key each field by its namespace so equal keys in different namespaces are kept
+	keys := make([]string, len(fields))
+	namespace := 0
+	for i, f := range fields {
+		switch f.Type {
+		case zapcore.NamespaceType:
+			namespace++
+		case zapcore.InlineMarshalerType, zapcore.SkipType:
+			// Inline fields carry no key of their own
+		default:
+			keys[i] = strconv.Itoa(namespace) + ":" + f.Key
+		}
+	}
+
+	winner := make(map[string]int, len(fields))
+	for i, key := range keys {
+		if key == "" {
+			continue
+		}
+		if _, seen := winner[key]; !seen || keepLast {
+			winner[key] = i
+		}
+	}
+
+	deduped := make([]zapcore.Field, 0, len(fields))
+	for i, f := range fields {
+		if keys[i] == "" || winner[keys[i]] == i {
+			deduped = append(deduped, f)
+		}
+	}
+
+	return deduped
+}
@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestDedupeCore(t *testing.T) {
+	tests := []struct {
+		name     string
+		keepLast bool
+		want     string
+	}{
+		{"Keep first", false, `{"M":"override","error":"from with","user":"alice","ns":{"error":"nested"}}`},
+		{"Keep last", true, `{"M":"override","user":"alice","error":"from call","ns":{"error":"nested"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			config := zapcore.EncoderConfig{MessageKey: "M"}
+			core := zapcore.NewCore(zapcore.NewJSONEncoder(config), zapcore.AddSync(buf), DebugLevel)
+
+			derived := zap.New(newDedupeCore(core, tt.keepLast)).With(zap.String("error", "from with"))
+			derived.Info("override",
+				zap.String("user", "alice"),
+				zap.String("error", "from call"),
+				zap.Namespace("ns"),
+				zap.String("error", "nested"),
+			)
+
+			assert.JSONEq(t, tt.want, buf.String())
+			assert.Equal(t, tt.want+"\n", buf.String())
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// defaultFieldsCore is a zapcore.Core adding dynamically computed fields to every entry
+type defaultFieldsCore struct {
+	zapcore.Core
+	levelChecked
+	funcs []func() []zap.Field
+}
+
+// WithDefaultFieldsFunc adds the fields returned by fn to every entry
+//
+// fn is called each time an entry is written, so the fields follow live state such as
+// the current shard, a rotating key ID or leader status, without rebuilding the logger.
+// It runs on the logging goroutine for every written entry and must be fast and safe
+// for concurrent use; it must not log itself. When the option is given several times,
+// the fields of every function are added, in order.
+//
+// Parameters:
+//   - fn: The function computing the fields
+//
+// Returns:
+//   - Option: A function that adds the default fields function to the option struct
+func WithDefaultFieldsFunc(fn func() []zap.Field) Option {
+	return func(o *option) {
+		o.defaultFieldsFuncs = append(o.defaultFieldsFuncs, fn)
+	}
+}
+
+// newDefaultFieldsCore wraps a core to add the fields computed by funcs to every entry
+//
+// Parameters:
+//   - core: The core to wrap
+//   - funcs: The functions computing the fields
+//
+// Returns:
+//   - zapcore.Core: A core adding the computed fields
+func newDefaultFieldsCore(core zapcore.Core, funcs []func() []zap.Field) zapcore.Core {
+	return &defaultFieldsCore{Core: core, funcs: funcs}
+}
+
+// With adds structured context to the core
+func (c *defaultFieldsCore) With(fields []zapcore.Field) zapcore.Core {
+	return &defaultFieldsCore{Core: c.Core.With(fields), funcs: c.funcs}
+}
+
+// Check adds the core so the fields are only computed for written entries
+func (c *defaultFieldsCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write writes the entry with the computed fields prepended, outside any namespace opened by the fields
+func (c *defaultFieldsCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	var all []zapcore.Field
+	for _, fn := range c.funcs {
+		all = append(all, fn()...)
+	}
+	all = append(all, fields...)
+
+	return writeEntry(c.Core, ent, all)
+}
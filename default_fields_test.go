@@ -0,0 +1,38 @@
+package logger
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithDefaultFieldsFunc(t *testing.T) {
+	var leader atomic.Bool
+	calls := 0
+	opt := &option{}
+	WithDefaultFieldsFunc(func() []zap.Field {
+		calls++
+		return []zap.Field{zap.Bool("leader", leader.Load())}
+	})(opt)
+	WithDefaultFieldsFunc(func() []zap.Field { return []zap.Field{zap.String("shard", "eu-1")} })(opt)
+
+	obs, recorded := observer.New(zapcore.InfoLevel)
+	logger := &Manager{Zap: zap.New(wrapCore(opt, obs))}
+
+	logger.Info(context.Background(), "follower", zap.Int("n", 1))
+	leader.Store(true)
+	logger.Info(context.Background(), "leader", zap.Int("n", 2))
+	logger.Log(context.Background(), DebugLevel, "not written")
+
+	entries := recorded.All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, map[string]interface{}{"leader": false, "shard": "eu-1", "n": int64(1)}, entries[0].ContextMap())
+	assert.Equal(t, map[string]interface{}{"leader": true, "shard": "eu-1", "n": int64(2)}, entries[1].ContextMap())
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,836 @@
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	// OptionDescription describes an option function of the package
+	OptionDescription struct {
+		Option      string `json:"option"`           // Name of the option function, e.g. "WithMaxAge"
+		Params      string `json:"params"`           // Parameters of the option function, e.g. "maxAge time.Duration"
+		Type        string `json:"type"`             // Type returned by the option function, "Option" or "MiddlewareOption"
+		Config      string `json:"config,omitempty"` // JSON path of the Config field reflecting the setting, empty when not in Config
+		Default     string `json:"default"`          // Setting applied without the option, "disabled" or "none" when it is off
+		Description string `json:"description"`      // What the option does, the first line of its documentation
+	}
+
+	// optionDefaults holds the defaults the registered options are described from
+	optionDefaults struct {
+		opt        *option           // Options applied by New without any Option
+		config     Config            // Config view of opt
+		middleware *middlewareOption // Options applied by Middleware without any MiddlewareOption
+	}
+
+	// registeredOption is an option function with the way to read its default
+	registeredOption struct {
+		description  OptionDescription
+		defaultValue func(d optionDefaults) string // Renders the default from the options built without any option
+	}
+)
+
+// registeredOptions lists every option function of the package, sorted by name
+//
+// A test fails when an exported option function is missing, so the list cannot drift
+// from the code.
+var registeredOptions = []registeredOption{
+	{
+		description: OptionDescription{
+			Option:      "WithAsyncMetrics",
+			Params:      "",
+			Type:        "Option",
+			Description: "Records how long each entry takes to be written, exposed by Stats",
+		},
+		defaultValue: func(d optionDefaults) string { return enabled(d.opt.writeLatency != nil) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithBaggage",
+			Params:      "maxKeys int",
+			Type:        "Option",
+			Description: "Logs the baggage found in the context under a \"baggage\" object",
+		},
+		defaultValue: func(d optionDefaults) string { return enabled(d.opt.baggage) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithBaseContext",
+			Params:      "ctx context.Context",
+			Type:        "Option",
+			Description: "Sets a base context for values missing from the per-call context",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.baseCtx != nil, "set") },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithBodyLogging",
+			Params:      "maxSize int",
+			Type:        "MiddlewareOption",
+			Description: "Logs textual request and response bodies at Debug level",
+		},
+		defaultValue: func(d optionDefaults) string { return enabled(d.middleware.logBodies) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithBuildInfo",
+			Params:      "",
+			Type:        "Option",
+			Description: "Adds the binary's version and VCS revision to every entry",
+		},
+		defaultValue: func(d optionDefaults) string { return enabled(d.opt.buildInfo) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithCallerFunction",
+			Params:      "",
+			Type:        "Option",
+			Config:      "encoder_keys.function",
+			Description: "Writes the caller's fully qualified function name under \"func\"",
+		},
+		defaultValue: func(d optionDefaults) string {
+			return none(d.config.EncoderKeys.Function != "", d.config.EncoderKeys.Function)
+		},
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithCallerMinLevel",
+			Params:      "level zapcore.Level",
+			Type:        "Option",
+			Description: "Only writes the caller of entries at or above the given level",
+		},
+		defaultValue: func(d optionDefaults) string { return levelOrNone(d.opt.callerMinLevel) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithCallerSampling",
+			Params:      "initial, thereafter int, tick time.Duration",
+			Type:        "Option",
+			Config:      "caller_sampling",
+			Description: "Enables sampling keyed by the entry's caller (file:line)",
+		},
+		defaultValue: func(d optionDefaults) string {
+			return none(d.config.CallerSampling != nil, fmt.Sprintf("%+v", d.config.CallerSampling))
+		},
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithCallerSkip",
+			Params:      "skip int",
+			Type:        "Option",
+			Config:      "caller_skip",
+			Description: "Sets the number of callers to skip when logging caller info",
+		},
+		defaultValue: func(d optionDefaults) string { return strconv.Itoa(d.config.CallerSkip) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithCallerThrottle",
+			Params:      "maxPerSec int",
+			Type:        "Option",
+			Description: "Caps the number of entries per second written by a single call site",
+		},
+		defaultValue: func(d optionDefaults) string {
+			return none(d.opt.callerThrottle > 0, strconv.Itoa(d.opt.callerThrottle))
+		},
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithCleanupInterval",
+			Params:      "interval time.Duration",
+			Type:        "Option",
+			Description: "Sets how often old log files are scanned and removed",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.cleanupInterval > 0, d.opt.cleanupInterval.String()) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithColor",
+			Params:      "useColor bool",
+			Type:        "Option",
+			Config:      "use_color",
+			Description: "Enables or disables colored output (only for console encoder)",
+		},
+		defaultValue: func(d optionDefaults) string { return strconv.FormatBool(d.config.UseColor) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithCompactErrorStack",
+			Params:      "",
+			Type:        "Option",
+			Description: "Removes the frames that never help from the stack traces",
+		},
+		defaultValue: func(d optionDefaults) string { return enabled(d.opt.compactStack) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithContextDeadline",
+			Params:      "",
+			Type:        "Option",
+			Description: "Logs the time left before the context's deadline with every entry",
+		},
+		defaultValue: func(d optionDefaults) string { return enabled(d.opt.contextDeadline) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithDedupeKeys",
+			Params:      "keepLast bool",
+			Type:        "Option",
+			Description: "Collapses fields sharing the same key into a single field",
+		},
+		defaultValue: func(d optionDefaults) string { return enabled(d.opt.dedupeKeys) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithDefaultFieldsFunc",
+			Params:      "fn func() []zap.Field",
+			Type:        "Option",
+			Description: "Adds the fields returned by fn to every entry",
+		},
+		defaultValue: func(d optionDefaults) string { return none(len(d.opt.defaultFieldsFuncs) > 0, "set") },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithDriver",
+			Params:      "driver string",
+			Type:        "Option",
+			Config:      "driver",
+			Description: "Sets the logger driver",
+		},
+		defaultValue: func(d optionDefaults) string { return d.config.Driver },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithEncoderConfig",
+			Params:      "config zapcore.EncoderConfig",
+			Type:        "Option",
+			Config:      "encoder_keys",
+			Description: "Sets the encoder configuration for log formatting",
+		},
+		defaultValue: func(d optionDefaults) string { return fmt.Sprintf("%+v", d.config.EncoderKeys) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithEncoding",
+			Params:      "encoding string",
+			Type:        "Option",
+			Config:      "encoding",
+			Description: "Sets the log encoding",
+		},
+		defaultValue: func(d optionDefaults) string { return d.config.Encoding },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithEncryption",
+			Params:      "key []byte",
+			Type:        "Option",
+			Description: "Encrypts the log files at rest with AES-GCM",
+		},
+		defaultValue: func(d optionDefaults) string { return enabled(d.opt.encryptionKey != nil) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithErrorTriggeredFlush",
+			Params:      "bufferSize int",
+			Type:        "Option",
+			Description: "Holds entries below Error in memory until an Error entry is written",
+		},
+		defaultValue: func(d optionDefaults) string {
+			return none(d.opt.errorFlushSize > 0, strconv.Itoa(d.opt.errorFlushSize))
+		},
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithEventLogSource",
+			Params:      "source string",
+			Type:        "Option",
+			Description: "Sets the event source used by the \"eventlog\" driver",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.eventLogSource != "", d.opt.eventLogSource) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithEvents",
+			Params:      "names ...string",
+			Type:        "Option",
+			Description: "Restricts Emit to a registry of known event names",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.events != nil, "set") },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithExitOnFatal",
+			Params:      "exit bool",
+			Type:        "Option",
+			Description: "Sets whether Fatal entries terminate the process, true by default",
+		},
+		defaultValue: func(d optionDefaults) string { return strconv.FormatBool(!d.opt.noExitOnFatal) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithExtraCore",
+			Params:      "core zapcore.Core",
+			Type:        "Option",
+			Description: "Tees a user-supplied core alongside the configured output",
+		},
+		defaultValue: func(d optionDefaults) string { return none(len(d.opt.extraCores) > 0, "set") },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithFieldColors",
+			Params:      "enabled bool",
+			Type:        "Option",
+			Description: "Styles the fields of console output with ANSI colors",
+		},
+		defaultValue: func(d optionDefaults) string { return strconv.FormatBool(d.opt.fieldColors) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithFieldOrder",
+			Params:      "keys []string",
+			Type:        "Option",
+			Description: "Writes the fields with the given keys first, in the given order",
+		},
+		defaultValue: func(d optionDefaults) string {
+			return none(len(d.opt.fieldOrder) > 0, strings.Join(d.opt.fieldOrder, ","))
+		},
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithFileFallback",
+			Params:      "retryInterval time.Duration",
+			Type:        "Option",
+			Description: "Falls back to stdout when the log file cannot be written",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.fileFallback > 0, d.opt.fileFallback.String()) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithFileFallbackHook",
+			Params:      "hook func(degraded bool, err error)",
+			Type:        "Option",
+			Description: "Sets a function called whenever WithFileFallback switches outputs",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.fileFallbackHook != nil, "set") },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithFilter",
+			Params:      "filter func(zapcore.Entry, []zapcore.Field) bool",
+			Type:        "Option",
+			Description: "Drops the entries for which the predicate returns false",
+		},
+		defaultValue: func(d optionDefaults) string { return none(len(d.opt.filters) > 0, "set") },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithFlushOnError",
+			Params:      "",
+			Type:        "Option",
+			Description: "Syncs the outputs right after every entry at ErrorLevel or above is written",
+		},
+		defaultValue: func(d optionDefaults) string { return enabled(d.opt.flushOnError) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithFullCaller",
+			Params:      "",
+			Type:        "Option",
+			Description: "Writes the caller as the absolute file path instead of the last directory",
+		},
+		defaultValue: func(d optionDefaults) string { return callerFormat(d.opt.encoderConfig.EncodeCaller) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithGoroutineContext",
+			Params:      "",
+			Type:        "Option",
+			Description: "Makes the manager fall back to the context set with SetContext",
+		},
+		defaultValue: func(d optionDefaults) string { return enabled(d.opt.goroutineContext) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithGoroutineID",
+			Params:      "",
+			Type:        "Option",
+			Description: "Adds a \"goroutine\" field holding the ID of the goroutine that logged the entry",
+		},
+		defaultValue: func(d optionDefaults) string { return enabled(d.opt.goroutineID) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithInstanceID",
+			Params:      "",
+			Type:        "Option",
+			Description: "Adds an ID identifying the running process to every entry",
+		},
+		defaultValue: func(d optionDefaults) string { return enabled(d.opt.instanceID != "") },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithJournald",
+			Params:      "",
+			Type:        "Option",
+			Config:      "driver",
+			Description: "Sends entries to the systemd journal",
+		},
+		defaultValue: func(d optionDefaults) string { return d.config.Driver },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithLevel",
+			Params:      "level string",
+			Type:        "Option",
+			Config:      "level",
+			Description: "Sets the minimum log level",
+		},
+		defaultValue: func(d optionDefaults) string { return d.config.Level },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithLevelSampling",
+			Params:      "maxSampledLevel zapcore.Level, initial, thereafter int, tick time.Duration",
+			Type:        "Option",
+			Description: "Enables sampling for entries at or below a given level",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.levelSampling != nil, "set") },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithLevelStrings",
+			Params:      "names map[zapcore.Level]string",
+			Type:        "Option",
+			Description: "Sets the strings written for the given levels",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.levelStrings != nil, "set") },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithLogPath",
+			Params:      "path string",
+			Type:        "Option",
+			Config:      "log_path",
+			Description: "Sets the log file path (only used when driver is \"file\")",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.config.LogPath != "", d.config.LogPath) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithMaxAge",
+			Params:      "maxAge time.Duration",
+			Type:        "Option",
+			Config:      "max_age",
+			Description: "Sets the maximum age for log files before rotation",
+		},
+		defaultValue: func(d optionDefaults) string { return d.config.MaxAge.String() },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithMaxFields",
+			Params:      "n int",
+			Type:        "Option",
+			Description: "Caps the number of fields written per entry",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.maxFields > 0, strconv.Itoa(d.opt.maxFields)) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithMessagePrefix",
+			Params:      "prefix string",
+			Type:        "Option",
+			Description: "Prepends a static prefix to every message",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.messagePrefix != "", d.opt.messagePrefix) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithMinRotationSize",
+			Params:      "bytes int",
+			Type:        "Option",
+			Description: "Defers time-based rotation until the file reaches a minimum size",
+		},
+		defaultValue: func(d optionDefaults) string {
+			return none(d.opt.minRotationSize > 0, strconv.FormatInt(d.opt.minRotationSize, 10))
+		},
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithNumericLevel",
+			Params:      "key string, mapping map[zapcore.Level]int",
+			Type:        "Option",
+			Description: "Adds the level as a number to every entry, next to the textual level",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.numericLevelKey != "", d.opt.numericLevelKey) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithOTLP",
+			Params:      "endpoint string",
+			Type:        "Option",
+			Description: "Exports the entries as OpenTelemetry log records to an OTLP/HTTP endpoint",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.otlpEndpoint != "", d.opt.otlpEndpoint) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithObserver",
+			Params:      "level zapcore.Level",
+			Type:        "Option",
+			Description: "Retains the most recent entries in memory, e.g. for a debug endpoint",
+		},
+		defaultValue: func(d optionDefaults) string { return enabled(d.opt.observed != nil) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithOnError",
+			Params:      "onError func(err error)",
+			Type:        "Option",
+			Description: "Sets a function called whenever writing an entry to an output fails",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.onError != nil, "set") },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithPackageCaller",
+			Params:      "",
+			Type:        "Option",
+			Description: "Writes the caller as the full import path of its package",
+		},
+		defaultValue: func(d optionDefaults) string { return callerFormat(d.opt.encoderConfig.EncodeCaller) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithPanicHook",
+			Params:      "hook func(msg string, fields []zap.Field)",
+			Type:        "Option",
+			Description: "Sets a function called when a Panic entry is logged, right before panicking",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.panicHook != nil, "set") },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithPlainFile",
+			Params:      "path string",
+			Type:        "Option",
+			Description: "Writes every entry to a single file, bypassing the built-in rotation",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.plainFile != "", d.opt.plainFile) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithRateLimitByLevel",
+			Params:      "limits map[zapcore.Level]int",
+			Type:        "Option",
+			Description: "Caps the number of entries per second of each level independently",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.rateLimits != nil, "set") },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithReopenOnSignal",
+			Params:      "",
+			Type:        "Option",
+			Description: "Reopens the plain log file when the process receives SIGHUP",
+		},
+		defaultValue: func(d optionDefaults) string { return enabled(d.opt.reopenOnSignal) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithRequestIDHeader",
+			Params:      "header string",
+			Type:        "MiddlewareOption",
+			Description: "Reads the RequestID of each request from the given header",
+		},
+		defaultValue: func(d optionDefaults) string {
+			return none(d.middleware.requestIDHeader != "", d.middleware.requestIDHeader)
+		},
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithRotationTime",
+			Params:      "rotationTime time.Duration",
+			Type:        "Option",
+			Config:      "rotation_time",
+			Description: "Sets the time between log file rotations",
+		},
+		defaultValue: func(d optionDefaults) string { return d.config.RotationTime.String() },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithRotationUTC",
+			Params:      "",
+			Type:        "Option",
+			Description: "Rotates the log files at UTC period boundaries and names them after the UTC date",
+		},
+		defaultValue: func(d optionDefaults) string { return enabled(d.opt.rotationUTC) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithRouteByField",
+			Params:      "key, dir string",
+			Type:        "Option",
+			Description: "Additionally writes each entry to a file named after the value of a field",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.routeKey != "", d.opt.routeKey) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithSafeEncoding",
+			Params:      "",
+			Type:        "Option",
+			Description: "Replaces the value of a field whose encoding panics by \"<encode-error>\"",
+		},
+		defaultValue: func(d optionDefaults) string { return enabled(d.opt.safeEncoding) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithSamplingExempt",
+			Params:      "prefixes ...string",
+			Type:        "Option",
+			Description: "Exempts the entries whose message starts with one of the prefixes from sampling",
+		},
+		defaultValue: func(d optionDefaults) string {
+			return none(len(d.opt.samplingExempt) > 0, strings.Join(d.opt.samplingExempt, ","))
+		},
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithSamplingHook",
+			Params:      "hook func(zapcore.Entry, zapcore.SamplingDecision)",
+			Type:        "Option",
+			Description: "Sets a function called with every sampling decision",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.samplingHook != nil, "set") },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithSchemaHeader",
+			Params:      "schema string",
+			Type:        "Option",
+			Description: "Writes a header line at the start of every new log file",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.schemaHeader != "", d.opt.schemaHeader) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithSeparateLevelFiles",
+			Params:      "dir string",
+			Type:        "Option",
+			Config:      "split_by_level_dir",
+			Description: "Writes the entries of each level to its own rotated file in dir",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.flatLevelFiles, d.config.SplitByLevelDir) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithSequence",
+			Params:      "",
+			Type:        "Option",
+			Description: "Adds a process-wide, monotonically increasing \"seq\" field to every entry",
+		},
+		defaultValue: func(d optionDefaults) string { return enabled(d.opt.sequence) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithSinkEncoded",
+			Params:      "encoding string, useColor bool, w io.Writer, level zapcore.Level",
+			Type:        "Option",
+			Description: "Writes the entries to an additional writer with its own encoding",
+		},
+		defaultValue: func(d optionDefaults) string { return none(len(d.opt.sinks) > 0, "set") },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithSplitByLevel",
+			Params:      "baseDir string",
+			Type:        "Option",
+			Config:      "split_by_level_dir",
+			Description: "Writes one file per level bucket under a date-partitioned directory",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.config.SplitByLevelDir != "", d.config.SplitByLevelDir) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithStackDedup",
+			Params:      "window time.Duration",
+			Type:        "Option",
+			Description: "Writes a given stack trace at most once per window",
+		},
+		defaultValue: func(d optionDefaults) string {
+			return none(d.opt.stackDedupWindow > 0, d.opt.stackDedupWindow.String())
+		},
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithStacktraceLevel",
+			Params:      "level string",
+			Type:        "Option",
+			Config:      "stacktrace_level",
+			Description: "Sets the minimum log level for stacktrace",
+		},
+		defaultValue: func(d optionDefaults) string { return d.config.StacktraceLevel },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithStartupSelfTest",
+			Params:      "",
+			Type:        "Option",
+			Description: "Makes New fail when the outputs cannot be written",
+		},
+		defaultValue: func(d optionDefaults) string { return enabled(d.opt.startupSelfTest) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithStructuredCaller",
+			Params:      "",
+			Type:        "Option",
+			Description: "Writes the caller as separate \"file\", \"line\" and \"function\" fields",
+		},
+		defaultValue: func(d optionDefaults) string { return enabled(d.opt.structuredCaller) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithStructuredStacktrace",
+			Params:      "key string",
+			Type:        "Option",
+			Description: "Emits stack traces as an array of {func, file, line} objects",
+		},
+		defaultValue: func(d optionDefaults) string { return enabled(d.opt.structuredStack) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithTimeFormat",
+			Params:      "layout string",
+			Type:        "Option",
+			Config:      "time_format",
+			Description: "Sets the layout used to format entry timestamps",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.config.TimeFormat != "", d.config.TimeFormat) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithTimezone",
+			Params:      "loc *time.Location",
+			Type:        "Option",
+			Config:      "timezone",
+			Description: "Converts entry timestamps to the given location before formatting",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.config.Timezone != "", d.config.Timezone) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithTraceIDHeader",
+			Params:      "header string",
+			Type:        "MiddlewareOption",
+			Description: "Reads the TraceID of each request from the given header",
+		},
+		defaultValue: func(d optionDefaults) string {
+			return none(d.middleware.traceIDHeader != "", d.middleware.traceIDHeader)
+		},
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithUTC",
+			Params:      "",
+			Type:        "Option",
+			Config:      "timezone",
+			Description: "Emits entry timestamps in UTC",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.config.Timezone != "", d.config.Timezone) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithValidateJSON",
+			Params:      "",
+			Type:        "Option",
+			Description: "Checks that every line written is valid JSON and panics otherwise",
+		},
+		defaultValue: func(d optionDefaults) string { return enabled(d.opt.validateJSON) },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithValidateJSONHook",
+			Params:      "hook func(line []byte)",
+			Type:        "Option",
+			Description: "Checks that every line written is valid JSON, calling hook otherwise",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.validateJSONHook != nil, "set") },
+	},
+	{
+		description: OptionDescription{
+			Option:      "WithWriteTimeout",
+			Params:      "timeout time.Duration",
+			Type:        "Option",
+			Description: "Abandons writes that do not complete within the given duration",
+		},
+		defaultValue: func(d optionDefaults) string { return none(d.opt.writeTimeout > 0, d.opt.writeTimeout.String()) },
+	},
+}
+
+// Describe lists every option function of the package with its parameters, default and description
+//
+// The defaults are read from the options New and Middleware apply when no option is given,
+// so documentation and configuration UIs generated from the list follow the code. It is
+// purely descriptive and creates no logger.
+//
+// Returns:
+//   - []OptionDescription: One description per option function, sorted by name
+func Describe() []OptionDescription {
+	opt := newOptions()
+	d := optionDefaults{opt: opt, config: newConfig(opt, opt.level, opt.callerSkip), middleware: &middlewareOption{}}
+
+	descriptions := make([]OptionDescription, 0, len(registeredOptions))
+	for _, registered := range registeredOptions {
+		description := registered.description
+		description.Default = registered.defaultValue(d)
+		descriptions = append(descriptions, description)
+	}
+
+	return descriptions
+}
+
+// enabled describes the default of an option switching a feature on
+func enabled(on bool) string {
+	if on {
+		return "enabled"
+	}
+
+	return "disabled"
+}
+
+// none describes the default of an option setting a value, "none" when the value is unset
+func none(set bool, value string) string {
+	if !set {
+		return "none"
+	}
+
+	return value
+}
+
+// levelOrNone describes an optional level
+func levelOrNone(level *zapcore.Level) string {
+	if level == nil {
+		return "none"
+	}
+
+	return levelName(*level)
+}
+
+// callerFormat describes the format of a caller encoder
+func callerFormat(encode zapcore.CallerEncoder) string {
+	switch reflect.ValueOf(encode).Pointer() {
+	case reflect.ValueOf(zapcore.ShortCallerEncoder).Pointer():
+		return "short"
+	case reflect.ValueOf(zapcore.FullCallerEncoder).Pointer():
+		return "full"
+	case reflect.ValueOf(PackageCallerEncoder).Pointer():
+		return "package"
+	}
+
+	return "custom"
+}
@@ -0,0 +1,119 @@
+package logger
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribe_AllOptions(t *testing.T) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	require.NoError(t, err)
+
+	declared := make(map[string]OptionDescription)
+	for _, file := range pkgs["logger"].Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || !fn.Name.IsExported() || fn.Type.Results == nil || len(fn.Type.Results.List) != 1 {
+				continue
+			}
+			result, ok := fn.Type.Results.List[0].Type.(*ast.Ident)
+			if !ok || result.Name != "Option" && result.Name != "MiddlewareOption" {
+				continue
+			}
+
+			params := make([]string, 0, len(fn.Type.Params.List))
+			for _, field := range fn.Type.Params.List {
+				names := make([]string, 0, len(field.Names))
+				for _, name := range field.Names {
+					names = append(names, name.Name)
+				}
+				var typ bytes.Buffer
+				require.NoError(t, printer.Fprint(&typ, fset, field.Type))
+				params = append(params, strings.Join(names, ", ")+" "+typ.String())
+			}
+
+			summary := strings.TrimPrefix(strings.SplitN(fn.Doc.Text(), "\n", 2)[0], fn.Name.Name+" ")
+			declared[fn.Name.Name] = OptionDescription{
+				Option:      fn.Name.Name,
+				Params:      strings.Join(params, ", "),
+				Type:        result.Name,
+				Description: string(unicode.ToUpper(rune(summary[0]))) + summary[1:],
+			}
+		}
+	}
+
+	described := make(map[string]OptionDescription)
+	for _, d := range Describe() {
+		assert.NotEmpty(t, d.Default, d.Option)
+		if d.Config != "" {
+			assert.True(t, hasConfigPath(reflect.TypeOf(Config{}), d.Config), "%s: unknown Config path %q", d.Option, d.Config)
+		}
+		d.Default, d.Config = "", ""
+		described[d.Option] = d
+	}
+
+	for name, want := range declared {
+		assert.Equal(t, want, described[name], "%s is not described, or its description is outdated", name)
+	}
+	for name := range described {
+		assert.Contains(t, declared, name, "%s is described but not declared", name)
+	}
+}
+
+func TestDescribe_Defaults(t *testing.T) {
+	logger, err := New()
+	require.NoError(t, err)
+	config := logger.Config()
+
+	defaults := make(map[string]string)
+	for _, d := range Describe() {
+		defaults[d.Option] = d.Default
+	}
+
+	assert.Equal(t, config.Driver, defaults["WithDriver"])
+	assert.Equal(t, config.Level, defaults["WithLevel"])
+	assert.Equal(t, config.Encoding, defaults["WithEncoding"])
+	assert.Equal(t, strconv.FormatBool(config.UseColor), defaults["WithColor"])
+	assert.Equal(t, strconv.Itoa(config.CallerSkip), defaults["WithCallerSkip"])
+	assert.Equal(t, config.MaxAge.String(), defaults["WithMaxAge"])
+	assert.Equal(t, config.RotationTime.String(), defaults["WithRotationTime"])
+	assert.Equal(t, config.StacktraceLevel, defaults["WithStacktraceLevel"])
+	assert.Equal(t, "none", defaults["WithCallerSampling"])
+	assert.Equal(t, "none", defaults["WithCallerMinLevel"])
+	assert.Equal(t, "short", defaults["WithFullCaller"])
+	assert.Equal(t, "true", defaults["WithExitOnFatal"])
+	assert.Equal(t, "disabled", defaults["WithEncryption"])
+	assert.Equal(t, "disabled", defaults["WithBodyLogging"])
+}
+
+// hasConfigPath reports whether a dot-separated path of JSON names leads to a field of typ
+func hasConfigPath(typ reflect.Type, path string) bool {
+	name, rest, nested := strings.Cut(path, ".")
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if strings.Split(field.Tag.Get("json"), ",")[0] != name {
+			continue
+		}
+		if !nested {
+			return true
+		}
+		return field.Type.Kind() == reflect.Struct && hasConfigPath(field.Type, rest)
+	}
+
+	return false
+}
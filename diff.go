@@ -0,0 +1,183 @@
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	// diffField is an object marshaler computing the differences between two values when encoded
+	diffField struct {
+		before interface{}
+		after  interface{}
+	}
+
+	// valueDiff holds the differences between two values, keyed by dotted field path
+	valueDiff struct {
+		added   map[string]interface{}
+		removed map[string]interface{}
+		changed map[string]valueChange
+	}
+
+	// valueChange is a value that differs between before and after
+	valueChange struct {
+		from interface{}
+		to   interface{}
+	}
+
+	// diffSection is one of the added, removed or changed objects of a diff
+	diffSection[V any] map[string]V
+)
+
+// Diff constructs a field holding only what changed between two values
+//
+// Structs are compared field by field, recursing into nested structs and maps; unexported
+// fields are ignored. A value missing on one side (nil pointer or map key) is reported
+// whole as added or removed. Differences are keyed by their dotted path and grouped into
+// "added", "removed" and "changed" objects, empty groups are omitted:
+//
+//	{"changes":{"changed":{"Address.City":{"from":"Paris","to":"Lyon"}}}}
+//
+// Other values, such as slices or times, are compared as a whole. The diff is only
+// computed if the entry is written.
+//
+// Parameters:
+//   - key: The field key
+//   - before: The value before the change
+//   - after: The value after the change
+//
+// Returns:
+//   - zap.Field: An object field describing the differences
+func Diff(key string, before, after interface{}) zap.Field {
+	return zap.Object(key, diffField{before: before, after: after})
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler
+func (d diffField) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	diff := valueDiff{
+		added:   map[string]interface{}{},
+		removed: map[string]interface{}{},
+		changed: map[string]valueChange{},
+	}
+	diff.compare("", reflect.ValueOf(d.before), reflect.ValueOf(d.after))
+
+	if len(diff.added) > 0 {
+		if err := enc.AddObject("added", diffSection[interface{}](diff.added)); err != nil {
+			return err
+		}
+	}
+	if len(diff.removed) > 0 {
+		if err := enc.AddObject("removed", diffSection[interface{}](diff.removed)); err != nil {
+			return err
+		}
+	}
+	if len(diff.changed) > 0 {
+		return enc.AddObject("changed", diffSection[valueChange](diff.changed))
+	}
+
+	return nil
+}
+
+// compare records the differences between a and b under path
+func (d valueDiff) compare(path string, a, b reflect.Value) {
+	a, b = indirect(a), indirect(b)
+
+	switch {
+	case !a.IsValid() && !b.IsValid():
+		return
+	case !a.IsValid():
+		d.added[leafPath(path)] = b.Interface()
+		return
+	case !b.IsValid():
+		d.removed[leafPath(path)] = a.Interface()
+		return
+	case a.Type() != b.Type():
+		d.changed[leafPath(path)] = valueChange{from: a.Interface(), to: b.Interface()}
+		return
+	}
+
+	typ := a.Type()
+	switch {
+	case typ.Kind() == reflect.Struct && hasExportedFields(typ):
+		for i := 0; i < typ.NumField(); i++ {
+			if typ.Field(i).IsExported() {
+				d.compare(joinPath(path, typ.Field(i).Name), a.Field(i), b.Field(i))
+			}
+		}
+	case typ.Kind() == reflect.Map:
+		keys := map[string]reflect.Value{}
+		for _, m := range []reflect.Value{a, b} {
+			for _, k := range m.MapKeys() {
+				keys[fmt.Sprint(k.Interface())] = k
+			}
+		}
+		for name, k := range keys {
+			d.compare(joinPath(path, name), a.MapIndex(k), b.MapIndex(k))
+		}
+	case !reflect.DeepEqual(a.Interface(), b.Interface()):
+		d.changed[leafPath(path)] = valueChange{from: a.Interface(), to: b.Interface()}
+	}
+}
+
+// indirect dereferences pointers and interfaces, returning the zero Value for nil
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		v = v.Elem()
+	}
+
+	return v
+}
+
+// hasExportedFields reports whether a struct type has exported fields to compare
+func hasExportedFields(typ reflect.Type) bool {
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).IsExported() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// joinPath appends a name to a dotted path
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+// leafPath returns the key of a difference, "value" when the compared values are not structs or maps
+func leafPath(path string) string {
+	if path == "" {
+		return "value"
+	}
+
+	return path
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, writing the keys in sorted order
+func (s diffSection[V]) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		zap.Any(key, s[key]).AddTo(enc)
+	}
+	return nil
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler
+func (c valueChange) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	zap.Any("from", c.from).AddTo(enc)
+	zap.Any("to", c.to).AddTo(enc)
+	return nil
+}
@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type diffAddress struct {
+	City    string
+	Country string
+}
+
+type diffUser struct {
+	Name      string
+	Age       int
+	Address   diffAddress
+	Manager   *diffUser
+	Tags      map[string]string
+	UpdatedAt time.Time
+	password  string
+}
+
+// encodeDiff encodes a Diff field as JSON and decodes it back
+func encodeDiff(t *testing.T, before, after interface{}) map[string]interface{} {
+	buf := &bytes.Buffer{}
+	logger := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{EncodeTime: zapcore.RFC3339TimeEncoder}), zapcore.AddSync(buf), InfoLevel))
+	logger.Info("", Diff("diff", before, after))
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	return got["diff"].(map[string]interface{})
+}
+
+func TestDiff(t *testing.T) {
+	updated := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	before := diffUser{
+		Name:      "Ada",
+		Age:       36,
+		Address:   diffAddress{City: "Paris", Country: "FR"},
+		Tags:      map[string]string{"plan": "free", "team": "core"},
+		UpdatedAt: updated,
+		password:  "old",
+	}
+	after := before
+	after.Address.City = "Lyon"
+	after.Manager = &diffUser{Name: "Grace"}
+	after.Tags = map[string]string{"plan": "pro", "region": "eu"}
+	after.UpdatedAt = updated.Add(time.Hour)
+	after.password = "new"
+
+	got := encodeDiff(t, before, &after)
+
+	assert.Equal(t, map[string]interface{}{
+		"Address.City": map[string]interface{}{"from": "Paris", "to": "Lyon"},
+		"Tags.plan":    map[string]interface{}{"from": "free", "to": "pro"},
+		"UpdatedAt":    map[string]interface{}{"from": "2024-01-02T03:04:05Z", "to": "2024-01-02T04:04:05Z"},
+	}, got["changed"])
+	added := got["added"].(map[string]interface{})
+	assert.Len(t, added, 2)
+	assert.Equal(t, "eu", added["Tags.region"])
+	assert.Equal(t, "Grace", added["Manager"].(map[string]interface{})["Name"])
+	assert.Equal(t, map[string]interface{}{"Tags.team": "core"}, got["removed"])
+}
+
+func TestDiff_SingleFieldChanged(t *testing.T) {
+	before := diffAddress{City: "Paris", Country: "FR"}
+	after := diffAddress{City: "Lyon", Country: "FR"}
+
+	got := encodeDiff(t, before, after)
+	assert.Equal(t, map[string]interface{}{
+		"changed": map[string]interface{}{
+			"City": map[string]interface{}{"from": "Paris", "to": "Lyon"},
+		},
+	}, got)
+}
+
+func TestDiff_NoChange(t *testing.T) {
+	user := diffUser{Name: "Ada"}
+	assert.Empty(t, encodeDiff(t, user, user))
+}
@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	// swappableCore is a zapcore.Core delegating to a base core that can be replaced at runtime
+	//
+	// Loggers derived through With keep their fields on the core and re-apply them to the
+	// new base core after a swap, so every logger switches to the new base core.
+	swappableCore struct {
+		base    *atomic.Pointer[coreBox] // Shared by every derived core
+		fields  []zapcore.Field          // Fields added through With
+		derived atomic.Pointer[derivedCore]
+	}
+
+	// coreBox holds a base core, compared by pointer to detect swaps
+	coreBox struct {
+		core zapcore.Core
+	}
+
+	// derivedCore caches a base core with the fields of a swappableCore applied
+	derivedCore struct {
+		base *coreBox
+		core zapcore.Core
+	}
+)
+
+// newSwappableCore wraps a core so it can be replaced with Swap
+//
+// Parameters:
+//   - core: The initial base core
+//
+// Returns:
+//   - *swappableCore: A core delegating to the current base core
+func newSwappableCore(core zapcore.Core) *swappableCore {
+	base := &atomic.Pointer[coreBox]{}
+	base.Store(&coreBox{core: core})
+
+	return &swappableCore{base: base}
+}
+
+// Swap replaces the base core of this core and every core derived from it
+func (c *swappableCore) Swap(core zapcore.Core) {
+	c.base.Store(&coreBox{core: core})
+}
+
+// current returns the current base core with the fields of this core applied
+func (c *swappableCore) current() zapcore.Core {
+	base := c.base.Load()
+	if len(c.fields) == 0 {
+		return base.core
+	}
+
+	if d := c.derived.Load(); d != nil && d.base == base {
+		return d.core
+	}
+
+	core := base.core.With(c.fields)
+	c.derived.Store(&derivedCore{base: base, core: core})
+
+	return core
+}
+
+// Enabled implements zapcore.LevelEnabler
+func (c *swappableCore) Enabled(level zapcore.Level) bool {
+	return c.current().Enabled(level)
+}
+
+// With adds structured context to the core, keeping the fields to re-apply them after a swap
+func (c *swappableCore) With(fields []zapcore.Field) zapcore.Core {
+	all := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	all = append(all, c.fields...)
+	all = append(all, fields...)
+
+	return &swappableCore{base: c.base, fields: all}
+}
+
+// Check delegates to the current core
+func (c *swappableCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return c.current().Check(ent, ce)
+}
+
+// Write delegates to the current core
+func (c *swappableCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.current().Write(ent, fields)
+}
+
+// Sync flushes the current core
+func (c *swappableCore) Sync() error {
+	return c.current().Sync()
+}
+
+// SetEncoding switches the encoding of every entry written from now on
+//
+// The core is rebuilt with the new encoding ("json", "console", "msgpack" or "gelf") while keeping
+// the level, the outputs and every other option, e.g. to switch a production logger to the
+// console encoding during a live debugging session. Loggers derived through With and Named
+// switch as well. The open outputs are reused and the startup self-test is not run again.
+// State kept by core wrappers, such as sampling counters, starts over.
+//
+// Parameters:
+//   - encoding: The new encoding
+//
+// Returns:
+//   - error: An error if the encoding is unknown or the manager was not created by New
+func (m *Manager) SetEncoding(encoding string) error {
+	if m.opt == nil || m.opt.root == nil {
+		return errors.New("the encoding can only be changed on managers created by New")
+	}
+
+	opt := m.lockOptions()
+	defer opt.rebuild.Unlock()
+
+	previous := opt.encoding
+	opt.encoding = encoding
+	core, err := newCore(opt, m.level)
+	if err != nil {
+		opt.encoding = previous
+		return err
+	}
+
+	opt.root.Swap(core)
+	return nil
+}
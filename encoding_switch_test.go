@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestManager_SetEncoding(t *testing.T) {
+	dir := t.TempDir() + string(filepath.Separator)
+	logger, err := New(WithDriver("file"), WithLogPath(dir))
+	require.NoError(t, err)
+	defer logger.Close()
+
+	ctx := context.Background()
+	derived := logger.With(ctx, zap.String("component", "billing"))
+	closers := len(logger.opt.closers)
+
+	logger.Info(ctx, "as json")
+	require.NoError(t, logger.SetEncoding("console"))
+	logger.Info(ctx, "as console")
+	derived.Info("derived as console")
+
+	assert.Error(t, logger.SetEncoding("xml"))
+	assert.Equal(t, "console", logger.Config().Encoding)
+	assert.Equal(t, closers, len(logger.opt.closers), "the log file is not opened again")
+
+	require.NoError(t, logger.Sync())
+	content, err := os.ReadFile(filepath.Join(dir, time.Now().Format("2006-01-02")+".log"))
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "{"), lines[0])
+	assert.Contains(t, lines[0], `"M":"as json"`)
+	assert.False(t, strings.HasPrefix(lines[1], "{"), lines[1])
+	assert.Contains(t, lines[1], "\tas console")
+	assert.Contains(t, lines[2], "\tderived as console\t")
+	assert.Contains(t, lines[2], `{"component": "billing"}`)
+}
+
+func TestManager_SetEncoding_NotFromNew(t *testing.T) {
+	logger := &Manager{Zap: zap.NewNop()}
+	assert.Error(t, logger.SetEncoding("console"))
+}
@@ -0,0 +1,133 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/file-rotatelogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+var testEncryptionKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestWithEncryption(t *testing.T) {
+	dir := t.TempDir() + string(filepath.Separator)
+	logger, err := New(WithDriver("file"), WithLogPath(dir), WithEncryption(testEncryptionKey))
+	require.NoError(t, err)
+
+	logger.Info(context.Background(), "order created")
+	logger.Warn(context.Background(), "order delayed")
+	require.NoError(t, logger.Close())
+
+	file := dir + time.Now().Format("2006-01-02") + ".log"
+	assert.NotContains(t, readFile(t, file), "order")
+
+	lines := strings.Split(strings.TrimSpace(decryptFile(t, file, testEncryptionKey)), "\n")
+	require.Len(t, lines, 2)
+	for i, msg := range []string{"order created", "order delayed"} {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(lines[i]), &entry))
+		assert.Equal(t, msg, entry["M"])
+	}
+}
+
+func TestWithEncryption_InvalidKey(t *testing.T) {
+	dir := t.TempDir() + string(filepath.Separator)
+	for _, driver := range []string{"file", "stdout", "journald"} {
+		for _, key := range [][]byte{[]byte("short"), nil} {
+			_, err := New(WithDriver(driver), WithLogPath(dir), WithEncryption(key))
+			assert.ErrorContains(t, err, "invalid encryption key", driver)
+		}
+	}
+}
+
+func TestWithEncryption_Driver(t *testing.T) {
+	for _, driver := range []string{"stdout", "journald", "eventlog"} {
+		_, err := New(WithDriver(driver), WithEncryption(testEncryptionKey))
+		assert.ErrorContains(t, err, "encryption requires the file driver", driver)
+	}
+
+	logger, err := New()
+	require.NoError(t, err)
+	assert.Error(t, logger.Reconfigure(WithEncryption(testEncryptionKey)), "Reconfigure")
+}
+
+func TestEncryptSyncer_Rotation(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 1, 2, 10, 30, 0, 0, time.Local)
+	clock := funcClock(func() time.Time { return now })
+
+	hook, err := rotatelogs.New(filepath.Join(dir, "%Y%m%d%H.log"), rotatelogs.WithRotationTime(time.Hour), rotatelogs.WithClock(clock))
+	require.NoError(t, err)
+	defer hook.Close()
+	encrypted, err := newEncryptSyncer(zapcore.AddSync(hook), testEncryptionKey)
+	require.NoError(t, err)
+	syncer := newSchemaHeaderSyncer(hook, encrypted, testSchemaHeader)
+
+	write := func(entry string) {
+		_, err := syncer.Write([]byte(entry))
+		require.NoError(t, err)
+	}
+
+	write("first\n")
+	write("second\n")
+	now = now.Add(time.Hour)
+	write("third\n")
+
+	assert.Equal(t, testSchemaHeader+"\nfirst\nsecond\n", decryptFile(t, filepath.Join(dir, "2024010210.log"), testEncryptionKey))
+	assert.Equal(t, testSchemaHeader+"\nthird\n", decryptFile(t, filepath.Join(dir, "2024010211.log"), testEncryptionKey))
+}
+
+func TestNewDecryptReader_Errors(t *testing.T) {
+	var file bytes.Buffer
+	syncer, err := newEncryptSyncer(zapcore.AddSync(&file), testEncryptionKey)
+	require.NoError(t, err)
+	for _, entry := range []string{"first\n", "second\n"} {
+		_, err := syncer.Write([]byte(entry))
+		require.NoError(t, err)
+	}
+
+	_, err = NewDecryptReader(bytes.NewReader(file.Bytes()), []byte("short"))
+	assert.Error(t, err, "invalid key")
+
+	r, err := NewDecryptReader(bytes.NewReader(file.Bytes()), []byte("fedcba9876543210fedcba9876543210"))
+	require.NoError(t, err)
+	_, err = io.ReadAll(r)
+	assert.ErrorContains(t, err, "failed to decrypt chunk", "wrong key")
+
+	r, err = NewDecryptReader(bytes.NewReader(file.Bytes()[:file.Len()-1]), testEncryptionKey)
+	require.NoError(t, err)
+	plaintext, err := io.ReadAll(r)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF, "truncated chunk")
+	assert.Equal(t, "first\n", string(plaintext))
+
+	tampered := bytes.Clone(file.Bytes())
+	tampered[len(tampered)-1] ^= 1
+	r, err = NewDecryptReader(bytes.NewReader(tampered), testEncryptionKey)
+	require.NoError(t, err)
+	_, err = io.ReadAll(r)
+	assert.ErrorContains(t, err, "failed to decrypt chunk", "modified chunk")
+}
+
+// decryptFile returns the plaintext of a file written with WithEncryption
+func decryptFile(t *testing.T, name string, key []byte) string {
+	f, err := os.Open(name)
+	require.NoError(t, err)
+	defer f.Close()
+
+	r, err := NewDecryptReader(f, key)
+	require.NoError(t, err)
+	plaintext, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	return string(plaintext)
+}
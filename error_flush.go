@@ -0,0 +1,143 @@
+package logger
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	// errorTriggeredCore is a zapcore.Core holding entries below Error until an Error is written
+	errorTriggeredCore struct {
+		zapcore.Core
+		levelChecked
+		ring *entryRing
+	}
+
+	// entryRing holds the latest buffered entries, shared by the cores derived through With
+	entryRing struct {
+		mu        sync.Mutex
+		triggered atomic.Bool // Set once an Error entry flushed the ring, entries then pass through
+		entries   []bufferedEntry
+		start     int // Index of the oldest entry
+		count     int // Number of buffered entries
+	}
+
+	// bufferedEntry is an entry held by an entryRing, with the core it was written to
+	bufferedEntry struct {
+		core   zapcore.Core
+		ent    zapcore.Entry
+		fields []zapcore.Field
+	}
+)
+
+// WithErrorTriggeredFlush holds entries below Error in memory until an Error entry is written
+//
+// Meant for short-lived tasks such as CLI commands, which stay silent when they succeed but
+// print their full context when they fail. The latest bufferSize entries below Error are
+// kept; the first entry at Error or above writes them, in order, followed by itself, and from
+// then on every entry is written directly. The entries still held when the process exits are
+// discarded, Sync does not write them.
+//
+// Parameters:
+//   - bufferSize: The maximum number of entries held, older ones are dropped
+//
+// Returns:
+//   - Option: A function that enables error-triggered flushing in the option struct
+func WithErrorTriggeredFlush(bufferSize int) Option {
+	return func(o *option) {
+		o.errorFlushSize = bufferSize
+	}
+}
+
+// newErrorTriggeredCore wraps a core to hold entries below Error until an Error entry
+//
+// Parameters:
+//   - core: The core to wrap
+//   - ring: The buffer holding the entries
+//
+// Returns:
+//   - zapcore.Core: A core writing its entries once an Error entry is written
+func newErrorTriggeredCore(core zapcore.Core, ring *entryRing) zapcore.Core {
+	return &errorTriggeredCore{Core: core, ring: ring}
+}
+
+// newEntryRing creates an empty buffer of entries
+//
+// Parameters:
+//   - size: The maximum number of entries held
+//
+// Returns:
+//   - *entryRing: A new buffer of entries
+func newEntryRing(size int) *entryRing {
+	return &entryRing{entries: make([]bufferedEntry, size)}
+}
+
+// With adds structured context to the core, sharing the buffer
+func (c *errorTriggeredCore) With(fields []zapcore.Field) zapcore.Core {
+	return &errorTriggeredCore{Core: c.Core.With(fields), ring: c.ring}
+}
+
+// Check adds the core if the entry's level is enabled
+func (c *errorTriggeredCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write buffers entries below Error, or writes the buffered entries and then the entry itself
+func (c *errorTriggeredCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if c.ring.triggered.Load() {
+		return writeEntry(c.Core, ent, fields)
+	}
+
+	c.ring.mu.Lock()
+	defer c.ring.mu.Unlock()
+
+	switch {
+	case c.ring.triggered.Load():
+	case ent.Level < zapcore.ErrorLevel:
+		c.ring.add(bufferedEntry{core: c.Core, ent: ent, fields: append([]zapcore.Field(nil), fields...)})
+		return nil
+	default:
+		err := c.ring.flush()
+		return errors.Join(err, writeEntry(c.Core, ent, fields))
+	}
+
+	return writeEntry(c.Core, ent, fields)
+}
+
+// add buffers an entry, dropping the oldest one when the buffer is full
+func (r *entryRing) add(entry bufferedEntry) {
+	if len(r.entries) == 0 {
+		return
+	}
+
+	if r.count == len(r.entries) {
+		r.entries[r.start] = entry
+		r.start = (r.start + 1) % len(r.entries)
+		return
+	}
+
+	r.entries[(r.start+r.count)%len(r.entries)] = entry
+	r.count++
+}
+
+// flush writes the buffered entries in order and switches to pass-through, returning the write errors
+func (r *entryRing) flush() error {
+	var err error
+	for i := 0; i < r.count; i++ {
+		entry := &r.entries[(r.start+i)%len(r.entries)]
+		err = errors.Join(err, writeEntry(entry.core, entry.ent, entry.fields))
+		*entry = bufferedEntry{}
+	}
+
+	r.start, r.count = 0, 0
+	r.triggered.Store(true)
+
+	return err
+}
@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithErrorTriggeredFlush(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger, err := New(WithLevel("debug"), WithErrorTriggeredFlush(2), WithSinkEncoded("console", false, out, zapcore.DebugLevel))
+	require.NoError(t, err)
+	defer logger.Close()
+
+	ctx := context.Background()
+	logger.Log(ctx, DebugLevel, "reading config")
+	logger.Info(ctx, "connecting", zap.String("host", "db"))
+	logger.With(ctx, zap.Int("attempt", 1)).Warn("slow connection")
+	assert.Empty(t, out.String(), "entries below Error are held")
+
+	logger.Error(ctx, "connection failed")
+	logger.Info(ctx, "retrying")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 4, "the oldest entry was dropped from the full buffer")
+	assert.Contains(t, lines[0], "connecting")
+	assert.Contains(t, lines[0], `{"host": "db"}`)
+	assert.Contains(t, lines[1], "slow connection")
+	assert.Contains(t, lines[1], `{"attempt": 1}`)
+	assert.Contains(t, lines[2], "connection failed")
+	assert.Contains(t, lines[3], "retrying", "entries pass through after the first Error")
+}
+
+func TestWithErrorTriggeredFlush_Success(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger, err := New(WithErrorTriggeredFlush(10), WithSinkEncoded("json", false, out, zapcore.DebugLevel))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	logger.Info(ctx, "task started")
+	logger.Warn(ctx, "cache miss")
+	logger.Info(ctx, "task done")
+	_ = logger.Sync() // Syncing stdout fails when it is not a file
+	require.NoError(t, logger.Close())
+
+	assert.Empty(t, out.String(), "the held entries are discarded on success")
+}
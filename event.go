@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// Event is an analytics event with a name and its properties
+type Event struct {
+	Name       string                 // Event name, logged under the "event" key
+	Properties map[string]interface{} // Event properties, logged under the "properties" key
+}
+
+// WithEvents restricts Emit to a registry of known event names
+//
+// Without a registry every event name is accepted.
+//
+// Parameters:
+//   - names: The known event names
+//
+// Returns:
+//   - Option: A function that registers the event names in the option struct
+func WithEvents(names ...string) Option {
+	return func(o *option) {
+		if o.events == nil {
+			o.events = make(map[string]struct{}, len(names))
+		}
+		for _, name := range names {
+			o.events[name] = struct{}{}
+		}
+	}
+}
+
+// Emit logs an analytics event at InfoLevel
+//
+// The entry has the message "event", the event name under the "event" key and the
+// properties under the "properties" key, giving analytics pipelines a fixed shape.
+//
+// Parameters:
+//   - ctx: The context.Context for this log entry
+//   - event: The event to log
+//
+// Returns:
+//   - error: An error if the event has no name or is not in the registry
+func (m *Manager) Emit(ctx context.Context, event Event) error {
+	if event.Name == "" {
+		return fmt.Errorf("event name is empty")
+	}
+
+	if opt := m.options(); opt != nil && opt.events != nil {
+		if _, ok := opt.events[event.Name]; !ok {
+			return fmt.Errorf("unknown event: %s", event.Name)
+		}
+	}
+
+	properties := event.Properties
+	if properties == nil {
+		properties = map[string]interface{}{}
+	}
+
+	logger := m.getLoggerWithTraceID(ctx)
+	logger.Info("event", zap.String("event", event.Name), zap.Any("properties", properties))
+
+	return nil
+}
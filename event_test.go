@@ -0,0 +1,38 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestManager_Emit(t *testing.T) {
+	core, recorded := observer.New(zapcore.InfoLevel)
+	opt := &option{}
+	WithEvents("user.signup")(opt)
+	logger := &Manager{Zap: zap.New(core), opt: opt}
+
+	err := logger.Emit(context.Background(), Event{
+		Name:       "user.signup",
+		Properties: map[string]interface{}{"plan": "pro", "seats": 3},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, recorded.Len())
+	entry := recorded.All()[0]
+	assert.Equal(t, zapcore.InfoLevel, entry.Level)
+	assert.Equal(t, "event", entry.Message)
+	assert.Equal(t, map[string]interface{}{
+		"event":      "user.signup",
+		"properties": map[string]interface{}{"plan": "pro", "seats": 3},
+	}, entry.ContextMap())
+
+	assert.Error(t, logger.Emit(context.Background(), Event{Name: "user.unknown"}))
+	assert.Error(t, logger.Emit(context.Background(), Event{}))
+	assert.Equal(t, 1, recorded.Len())
+}
@@ -0,0 +1,18 @@
+package logger
+
+// WithEventLogSource sets the event source used by the "eventlog" driver
+//
+// The "eventlog" driver is only available on Windows. It reports Error and above as
+// Error events, Warn as Warning events and every other level as Information events.
+// Register the source (e.g. with New-EventLog) so the Event Viewer can render the messages.
+//
+// Parameters:
+//   - source: The event source name
+//
+// Returns:
+//   - Option: A function that sets the event source in the option struct
+func WithEventLogSource(source string) Option {
+	return func(o *option) {
+		o.eventLogSource = source
+	}
+}
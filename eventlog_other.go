@@ -0,0 +1,15 @@
+//go:build !windows
+
+package logger
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// newEventLogCore reports that the Windows Event Log is not available on this platform
+func newEventLogCore(_ *option, _ zapcore.Encoder, _ zap.AtomicLevel) (zapcore.Core, error) {
+	return nil, errors.New("eventlog driver is only supported on windows")
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_EventLogUnsupported(t *testing.T) {
+	_, err := New(WithDriver("eventlog"), WithEventLogSource("myapp"))
+	assert.Error(t, err)
+}
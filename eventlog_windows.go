@@ -0,0 +1,131 @@
+//go:build windows
+
+package logger
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Windows event types used for the severity mapping
+const (
+	eventlogErrorType       = 0x0001
+	eventlogWarningType     = 0x0002
+	eventlogInformationType = 0x0004
+)
+
+var (
+	advapi32                  = syscall.NewLazyDLL("advapi32.dll")
+	procRegisterEventSourceW  = advapi32.NewProc("RegisterEventSourceW")
+	procDeregisterEventSource = advapi32.NewProc("DeregisterEventSource")
+	procReportEventW          = advapi32.NewProc("ReportEventW")
+)
+
+// eventLogCore is a zapcore.Core writing entries to the Windows Event Log
+type eventLogCore struct {
+	zapcore.LevelEnabler
+	levelChecked
+	enc    zapcore.Encoder
+	handle uintptr // Handle returned by RegisterEventSourceW
+}
+
+// newEventLogCore creates a core writing to the Windows Event Log
+//
+// Parameters:
+//   - opt: The option struct containing configuration
+//   - encoder: The zapcore.Encoder used to format the event message
+//   - level: The zap.AtomicLevel for dynamic level changes
+//
+// Returns:
+//   - zapcore.Core: A new Core for the Windows Event Log
+//   - error: An error if the event source cannot be registered
+func newEventLogCore(opt *option, encoder zapcore.Encoder, level zap.AtomicLevel) (zapcore.Core, error) {
+	if opt.eventLogHandle == 0 {
+		source, err := syscall.UTF16PtrFromString(opt.eventLogSource)
+		if err != nil {
+			return nil, err
+		}
+
+		handle, _, callErr := procRegisterEventSourceW.Call(0, uintptr(unsafe.Pointer(source)))
+		if handle == 0 {
+			return nil, fmt.Errorf("failed to register event source %s: %w", opt.eventLogSource, callErr)
+		}
+
+		opt.eventLogHandle = handle
+		opt.closers = append(opt.closers, func() error {
+			if ok, _, callErr := procDeregisterEventSource.Call(handle); ok == 0 {
+				return callErr
+			}
+			return nil
+		})
+	}
+
+	return &eventLogCore{LevelEnabler: level, enc: encoder, handle: opt.eventLogHandle}, nil
+}
+
+// With adds structured context to the core
+func (c *eventLogCore) With(fields []zapcore.Field) zapcore.Core {
+	enc := c.enc.Clone()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	return &eventLogCore{LevelEnabler: c.LevelEnabler, enc: enc, handle: c.handle}
+}
+
+// Check adds the core if the entry's level is enabled
+func (c *eventLogCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+
+	return ce
+}
+
+// Write reports the encoded entry as an event with a severity matching its level
+func (c *eventLogCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	defer buf.Free()
+
+	message, err := syscall.UTF16PtrFromString(buf.String())
+	if err != nil {
+		return err
+	}
+
+	eventType := eventlogInformationType
+	switch {
+	case ent.Level >= ErrorLevel:
+		eventType = eventlogErrorType
+	case ent.Level == WarnLevel:
+		eventType = eventlogWarningType
+	}
+
+	ok, _, callErr := procReportEventW.Call(
+		c.handle,
+		uintptr(eventType),
+		0, // Category
+		1, // Event ID
+		0, // User SID
+		1, // Number of strings
+		0, // Raw data size
+		uintptr(unsafe.Pointer(&message)),
+		0, // Raw data
+	)
+	if ok == 0 {
+		return fmt.Errorf("failed to report event: %w", callErr)
+	}
+
+	return nil
+}
+
+// Sync is a no-op, events are reported synchronously
+func (c *eventLogCore) Sync() error {
+	return nil
+}
@@ -0,0 +1,20 @@
+//go:build windows
+
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_EventLog(t *testing.T) {
+	logger, err := New(WithDriver("eventlog"), WithEventLogSource("sk-pkg-logger-test"))
+	require.NoError(t, err)
+	defer logger.Close()
+
+	logger.Info(context.Background(), "info event")
+	logger.Warn(context.Background(), "warning event")
+	logger.Error(context.Background(), "error event")
+}
@@ -0,0 +1,119 @@
+package logger
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// fallbackSyncer is a zapcore.WriteSyncer writing to a fallback while its primary output fails
+//
+// After a failed write, entries go to the fallback until the retry interval has elapsed;
+// the next write then tries the primary output again and switches back if it succeeds.
+type fallbackSyncer struct {
+	primary  zapcore.WriteSyncer
+	fallback zapcore.WriteSyncer
+	retry    time.Duration
+	onChange func(degraded bool, err error) // Called on every switch, may be nil
+	now      func() time.Time
+
+	mu       sync.Mutex
+	degraded bool      // Whether entries currently go to the fallback
+	retryAt  time.Time // Time of the next attempt to write to the primary output
+}
+
+// WithFileFallback falls back to stdout when the log file cannot be written
+//
+// When the disk is full or the log directory is removed, entries are written to stdout
+// instead of being lost. The file is tried again by the first write after retryInterval,
+// and logging switches back to it as soon as a write succeeds. Entries written to stdout
+// in the meantime are not copied to the file. Only the "file" driver is affected.
+//
+// Parameters:
+//   - retryInterval: The minimum duration between two attempts to write to the file
+//
+// Returns:
+//   - Option: A function that sets the file fallback in the option struct
+func WithFileFallback(retryInterval time.Duration) Option {
+	return func(o *option) {
+		o.fileFallback = retryInterval
+	}
+}
+
+// WithFileFallbackHook sets a function called whenever WithFileFallback switches outputs
+//
+// The hook receives degraded true and the write error when switching to stdout, and
+// degraded false and a nil error when the file is written again, e.g. to raise an alert or
+// export the state as a metric. It runs on the logging goroutine after the switch, so
+// entries it logs itself go to the new output.
+//
+// Parameters:
+//   - hook: The function called on every switch
+//
+// Returns:
+//   - Option: A function that sets the file fallback hook in the option struct
+func WithFileFallbackHook(hook func(degraded bool, err error)) Option {
+	return func(o *option) {
+		o.fileFallbackHook = hook
+	}
+}
+
+// withFileFallback wraps the syncer of a log file with the fallback to stdout if enabled
+//
+// Parameters:
+//   - opt: The option struct containing configuration
+//   - syncer: The syncer of a log file
+//
+// Returns:
+//   - zapcore.WriteSyncer: The syncer, wrapped if WithFileFallback is set
+func withFileFallback(opt *option, syncer zapcore.WriteSyncer) zapcore.WriteSyncer {
+	if opt.fileFallback <= 0 {
+		return syncer
+	}
+
+	return &fallbackSyncer{
+		primary:  syncer,
+		fallback: zapcore.AddSync(os.Stdout),
+		retry:    opt.fileFallback,
+		onChange: opt.fileFallbackHook,
+		now:      time.Now,
+	}
+}
+
+// Write writes to the primary output, or to the fallback while the primary output fails
+func (s *fallbackSyncer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	if s.degraded && s.now().Before(s.retryAt) {
+		defer s.mu.Unlock()
+		return s.fallback.Write(p)
+	}
+
+	n, err := s.primary.Write(p)
+	changed := s.degraded != (err != nil)
+	s.degraded = err != nil
+	if err != nil {
+		s.retryAt = s.now().Add(s.retry)
+		n, _ = s.fallback.Write(p)
+	}
+	s.mu.Unlock()
+
+	if changed && s.onChange != nil {
+		s.onChange(err != nil, err)
+	}
+
+	return n, nil
+}
+
+// Sync flushes the output currently written to
+func (s *fallbackSyncer) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.degraded {
+		return s.fallback.Sync()
+	}
+
+	return s.primary.Sync()
+}
@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+// toggleWriter fails its writes while err is set
+type toggleWriter struct {
+	bytes.Buffer
+	err error
+}
+
+func (w *toggleWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	return w.Buffer.Write(p)
+}
+
+func TestFallbackSyncer(t *testing.T) {
+	errDiskFull := errors.New("disk full")
+	file := &toggleWriter{}
+	stdout := &bytes.Buffer{}
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	type transition struct {
+		degraded bool
+		err      error
+	}
+	var transitions []transition
+
+	opt := &option{}
+	WithFileFallback(time.Minute)(opt)
+	WithFileFallbackHook(func(degraded bool, err error) {
+		transitions = append(transitions, transition{degraded, err})
+	})(opt)
+	syncer := withFileFallback(opt, zapcore.AddSync(file)).(*fallbackSyncer)
+	syncer.fallback = zapcore.AddSync(stdout)
+	syncer.now = func() time.Time { return now }
+
+	write := func(line string) {
+		n, err := syncer.Write([]byte(line))
+		require.NoError(t, err)
+		assert.Equal(t, len(line), n)
+	}
+
+	write("a\n")
+	file.err = errDiskFull
+	write("b\n")
+	write("c\n")
+	file.err = nil
+	write("d\n") // Before the retry interval, still on stdout
+	now = now.Add(time.Minute)
+	write("e\n")
+
+	assert.Equal(t, "a\ne\n", file.String())
+	assert.Equal(t, "b\nc\nd\n", stdout.String())
+	assert.Equal(t, []transition{{true, errDiskFull}, {false, nil}}, transitions)
+}
+
+func TestWithFileFallback_Disabled(t *testing.T) {
+	syncer := zapcore.AddSync(&bytes.Buffer{})
+	assert.Equal(t, syncer, withFileFallback(&option{}, syncer))
+}
+
+func TestWithFileFallback_PlainFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	opt := &option{plainFile: path}
+	WithFileFallback(time.Second)(opt)
+
+	syncer, err := newPlainFileSyncer(opt)
+	require.NoError(t, err)
+	defer opt.close()
+
+	_, ok := syncer.(*fallbackSyncer)
+	assert.True(t, ok)
+	_, err = os.Stat(path)
+	assert.NoError(t, err)
+}
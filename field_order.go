@@ -0,0 +1,118 @@
+package logger
+
+import (
+	"go.uber.org/zap/zapcore"
+)
+
+// fieldOrderCore is a zapcore.Core writing the listed keys first, in a fixed order
+//
+// Context fields added through With are kept on the core instead of being encoded upfront,
+// so they can be ordered together with the fields passed at the call site.
+type fieldOrderCore struct {
+	zapcore.Core
+	levelChecked
+	rank    map[string]int  // Position of each listed key
+	context []zapcore.Field // Fields added through With
+}
+
+// WithFieldOrder writes the fields with the given keys first, in the given order
+//
+// Fields added through With and at the call site can otherwise interleave differently from
+// one call site to the other, which makes log lines hard to diff. The listed keys are
+// written first in the order of keys, the other fields follow in their original order.
+// Only top-level fields are reordered; a namespace and the fields after it stay at the end.
+// Context fields are encoded with every entry instead of once, which costs some throughput.
+//
+// Parameters:
+//   - keys: The keys written first, in order
+//
+// Returns:
+//   - Option: A function that sets the field order in the option struct
+func WithFieldOrder(keys []string) Option {
+	return func(o *option) {
+		o.fieldOrder = keys
+	}
+}
+
+// newFieldOrderCore wraps a core to write the listed keys first
+//
+// Parameters:
+//   - core: The core to wrap
+//   - keys: The keys written first, in order
+//
+// Returns:
+//   - zapcore.Core: A core writing the fields in a canonical order
+func newFieldOrderCore(core zapcore.Core, keys []string) zapcore.Core {
+	rank := make(map[string]int, len(keys))
+	for _, key := range keys {
+		if _, ok := rank[key]; !ok {
+			rank[key] = len(rank)
+		}
+	}
+
+	return &fieldOrderCore{Core: core, rank: rank}
+}
+
+// With keeps the fields on the core so they can be ordered at write time
+func (c *fieldOrderCore) With(fields []zapcore.Field) zapcore.Core {
+	context := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	context = append(context, c.context...)
+	context = append(context, fields...)
+
+	return &fieldOrderCore{Core: c.Core, rank: c.rank, context: context}
+}
+
+// Check adds the core so Write can order the context and call-site fields
+func (c *fieldOrderCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write writes the entry with the listed keys first
+func (c *fieldOrderCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	all := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	all = append(all, c.context...)
+	all = append(all, fields...)
+
+	return writeEntry(c.Core, ent, orderFields(all, c.rank))
+}
+
+// orderFields moves the top-level fields with a ranked key to the front, by rank
+//
+// Parameters:
+//   - fields: The fields in encoding order
+//   - rank: The position of each listed key
+//
+// Returns:
+//   - []zapcore.Field: The ranked fields, then the others in their original order
+func orderFields(fields []zapcore.Field, rank map[string]int) []zapcore.Field {
+	// Fields from the first namespace on belong to it and keep their position
+	end := len(fields)
+	for i, f := range fields {
+		if f.Type == zapcore.NamespaceType {
+			end = i
+			break
+		}
+	}
+
+	ranked := make([][]zapcore.Field, len(rank))
+	rest := make([]zapcore.Field, 0, len(fields))
+	for _, f := range fields[:end] {
+		if r, ok := rank[f.Key]; ok && f.Type != zapcore.InlineMarshalerType && f.Type != zapcore.SkipType {
+			ranked[r] = append(ranked[r], f)
+			continue
+		}
+		rest = append(rest, f)
+	}
+
+	ordered := make([]zapcore.Field, 0, len(fields))
+	for _, group := range ranked {
+		ordered = append(ordered, group...)
+	}
+	ordered = append(ordered, rest...)
+
+	return append(ordered, fields[end:]...)
+}
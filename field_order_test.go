@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// jsonKeys returns the top-level keys of a JSON object in their order
+func jsonKeys(t *testing.T, line string) []string {
+	dec := json.NewDecoder(strings.NewReader(line))
+	_, err := dec.Token()
+	require.NoError(t, err)
+
+	var keys []string
+	for dec.More() {
+		key, err := dec.Token()
+		require.NoError(t, err)
+		keys = append(keys, key.(string))
+
+		var value json.RawMessage
+		require.NoError(t, dec.Decode(&value))
+	}
+
+	return keys
+}
+
+func TestWithFieldOrder(t *testing.T) {
+	opt := &option{}
+	WithFieldOrder([]string{"request_id", "user", "order"})(opt)
+
+	out := &bytes.Buffer{}
+	config := zapcore.EncoderConfig{MessageKey: "M"}
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(config), zapcore.AddSync(out), zapcore.InfoLevel)
+	logger := zap.New(wrapCore(opt, core))
+
+	derived := logger.With(zap.String("service", "billing"), zap.String("user", "u-1"))
+	derived.Info("first", zap.String("order", "o-1"), zap.String("request_id", "r-1"), zap.Int("amount", 5))
+	derived.Info("second", zap.Namespace("details"), zap.String("request_id", "nested"))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, []string{"M", "request_id", "user", "order", "service", "amount"}, jsonKeys(t, lines[0]))
+	assert.Equal(t, []string{"M", "user", "service", "details"}, jsonKeys(t, lines[1]))
+}
@@ -0,0 +1,349 @@
+package logger
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// MetricKey is the key of the object holding the metric of Counter and Gauge fields
+const MetricKey = "metric"
+
+type (
+	// lazyField is an inline marshaler that computes its value when encoded
+	lazyField struct {
+		key string
+		fn  func() interface{}
+	}
+
+	// counterMetric is the metric object of a Counter field
+	counterMetric struct {
+		name  string
+		delta int64
+	}
+
+	// gaugeMetric is the metric object of a Gauge field
+	gaugeMetric struct {
+		name  string
+		value float64
+	}
+
+	// validationErrors is the object of a ValidationErrors field
+	validationErrors map[string][]string
+
+	// timeRange is the object of a TimeRange field
+	timeRange struct {
+		start time.Time
+		end   time.Time
+	}
+)
+
+// Latency constructs a field holding a duration as fractional milliseconds
+//
+// The value is always emitted in milliseconds, independent of the encoder's
+// EncodeDuration setting, so dashboards can rely on a single unit.
+//
+// Parameters:
+//   - key: The field key
+//   - d: The duration to log
+//
+// Returns:
+//   - zap.Field: A float64 field with the duration in milliseconds
+func Latency(key string, d time.Duration) zap.Field {
+	return zap.Float64(key, float64(d)/float64(time.Millisecond))
+}
+
+// Lazy constructs a field whose value is only computed if the entry is written
+//
+// fn is called at encoding time, so entries suppressed by the level never pay for it.
+// The value is encoded like zap.Any. When passed to With, fn is called once, when the
+// derived logger is created.
+//
+// Parameters:
+//   - key: The field key
+//   - fn: The function computing the value
+//
+// Returns:
+//   - zap.Field: A field deferring the call to fn
+func Lazy(key string, fn func() interface{}) zap.Field {
+	return zap.Inline(lazyField{key: key, fn: fn})
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler
+func (l lazyField) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	zap.Any(l.key, l.fn()).AddTo(enc)
+	return nil
+}
+
+// Bytes constructs a field holding binary data as text, truncated to a maximum length
+//
+// Only the first maxLen bytes are encoded; a truncated value ends with " (N bytes total)",
+// which keeps lines readable when logging packets or signatures.
+//
+// Parameters:
+//   - key: The field key
+//   - b: The data to log
+//   - maxLen: The maximum number of bytes encoded, <= 0 for no limit
+//   - encoding: "hex" or "base64"; any other value uses base64
+//
+// Returns:
+//   - zap.Field: A string field with the encoded data
+func Bytes(key string, b []byte, maxLen int, encoding string) zap.Field {
+	data := b
+	if maxLen > 0 && len(b) > maxLen {
+		data = b[:maxLen]
+	}
+
+	var encoded string
+	if encoding == "hex" {
+		encoded = hex.EncodeToString(data)
+	} else {
+		encoded = base64.StdEncoding.EncodeToString(data)
+	}
+
+	if len(data) < len(b) {
+		encoded += " (" + strconv.Itoa(len(b)) + " bytes total)"
+	}
+
+	return zap.String(key, encoded)
+}
+
+// Fields converts a map into fields, sorted by key for a deterministic output
+//
+// Each value is typed like zap.Any, so strings, numbers, booleans, durations, errors and
+// nested maps keep their native encoding.
+//
+// Parameters:
+//   - m: The map to convert
+//
+// Returns:
+//   - []zap.Field: One field per map entry, in key order
+func Fields(m map[string]interface{}) []zap.Field {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fields := make([]zap.Field, 0, len(keys))
+	for _, k := range keys {
+		fields = append(fields, zap.Any(k, m[k]))
+	}
+
+	return fields
+}
+
+// InfoMap logs a message at InfoLevel with the entries of a map as fields
+//
+// Parameters:
+//   - ctx: The context.Context for this log entry
+//   - msg: The message to log
+//   - m: The map whose entries are logged, see Fields
+func (m *Manager) InfoMap(ctx context.Context, msg string, fields map[string]interface{}) {
+	converted := Fields(fields)
+	logger := m.getLoggerWithTraceID(ctx, converted...)
+	logger.Info(msg, converted...)
+}
+
+// Counter constructs a field tagging the entry as a counter increment
+//
+// The field is an object under MetricKey: {"metric":{"type":"counter","name":name,"delta":delta}}.
+// An entry carries at most one metric.
+//
+// Parameters:
+//   - name: The metric name
+//   - delta: The amount the counter is incremented by
+//
+// Returns:
+//   - zap.Field: An object field describing the metric
+func Counter(name string, delta int64) zap.Field {
+	return zap.Object(MetricKey, counterMetric{name: name, delta: delta})
+}
+
+// Gauge constructs a field tagging the entry as a gauge measurement
+//
+// The field is an object under MetricKey: {"metric":{"type":"gauge","name":name,"value":value}}.
+// An entry carries at most one metric.
+//
+// Parameters:
+//   - name: The metric name
+//   - value: The current value of the gauge
+//
+// Returns:
+//   - zap.Field: An object field describing the metric
+func Gauge(name string, value float64) zap.Field {
+	return zap.Object(MetricKey, gaugeMetric{name: name, value: value})
+}
+
+// TimeRange constructs a field holding a time window as a nested object
+//
+// The object holds "start" and "end", written with the encoder's time format, and
+// "duration", the time between them written with the encoder's duration format, e.g.
+// {"window":{"start":"2024-01-02T03:00:00.000Z","end":"2024-01-02T03:01:30.000Z","duration":90}}.
+//
+// Parameters:
+//   - key: The field key
+//   - start: The start of the range
+//   - end: The end of the range
+//
+// Returns:
+//   - zap.Field: An object field describing the range
+func TimeRange(key string, start, end time.Time) zap.Field {
+	return zap.Object(key, timeRange{start: start, end: end})
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler
+func (r timeRange) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddTime("start", r.start)
+	enc.AddTime("end", r.end)
+	enc.AddDuration("duration", r.end.Sub(r.start))
+	return nil
+}
+
+// Stack constructs a field holding the current goroutine's stack trace
+//
+// It attaches a stack trace to an entry of any level, e.g. an Info "how did we get here"
+// diagnostic, independently of WithStacktraceLevel. The trace starts at the function
+// calling Stack. With WithStructuredStacktrace, only the entry's own stack trace is
+// split into frames; this field stays a string.
+//
+// Parameters:
+//   - key: The field key
+//
+// Returns:
+//   - zap.Field: A string field with the stack trace
+func Stack(key string) zap.Field {
+	return zap.StackSkip(key, 1)
+}
+
+// IP constructs a field holding an IP address in its readable form
+//
+// zap.Any writes a net.IP as an array of bytes; IP writes "192.0.2.1" or "2001:db8::1".
+// A nil or invalid address is written as "<nil>" or "?" followed by its hex bytes.
+//
+// Parameters:
+//   - key: The field key
+//   - ip: The IP address
+//
+// Returns:
+//   - zap.Field: A string field with the address
+func IP(key string, ip net.IP) zap.Field {
+	return zap.String(key, ip.String())
+}
+
+// IPWithVersion constructs the fields of an IP address and of its version
+//
+// The address is written under key as by IP, and its version, 4 or 6, under key + "_version",
+// e.g. "ip":"192.0.2.1","ip_version":4. IPv4-mapped IPv6 addresses are version 4. The
+// version is omitted for a nil or invalid address.
+//
+// Parameters:
+//   - key: The field key of the address
+//   - ip: The IP address
+//
+// Returns:
+//   - []zap.Field: The address field, followed by the version field if known
+func IPWithVersion(key string, ip net.IP) []zap.Field {
+	fields := []zap.Field{IP(key, ip)}
+	switch {
+	case ip.To4() != nil:
+		fields = append(fields, zap.Int(key+"_version", 4))
+	case len(ip) == net.IPv6len:
+		fields = append(fields, zap.Int(key+"_version", 6))
+	}
+
+	return fields
+}
+
+// CIDR constructs a field holding a network in CIDR notation, e.g. "10.0.0.0/8"
+//
+// Parameters:
+//   - key: The field key
+//   - n: The network
+//
+// Returns:
+//   - zap.Field: A string field with the network, "<nil>" for a nil network
+func CIDR(key string, n *net.IPNet) zap.Field {
+	return zap.String(key, n.String())
+}
+
+// Enum constructs a field holding the name of an integer enum value
+//
+// State machines and other int-based constants are written as their name instead of a
+// meaningless number. Values missing from names are written as their number, e.g. "7".
+//
+// Parameters:
+//   - key: The field key
+//   - value: The enum value
+//   - names: The name of each enum value
+//
+// Returns:
+//   - zap.Field: A string field with the value's name
+func Enum[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64](key string, value T, names map[T]string) zap.Field {
+	if name, ok := names[value]; ok {
+		return zap.String(key, name)
+	}
+
+	return zap.String(key, fmt.Sprintf("%d", value))
+}
+
+// ValidationErrors constructs a field holding validation failures per input field
+//
+// The field is an object under "validation" mapping each input field to its messages, e.g.
+// {"validation":{"email":["is required"],"age":["must be positive"]}}, so failures can be
+// queried per field. Input fields are written in sorted order.
+//
+// Parameters:
+//   - m: The messages of each invalid input field
+//
+// Returns:
+//   - zap.Field: An object field describing the validation failures
+func ValidationErrors(m map[string][]string) zap.Field {
+	return zap.Object("validation", validationErrors(m))
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler
+func (v validationErrors) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	keys := make([]string, 0, len(v))
+	for k := range v {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if err := enc.AddArray(k, zapcore.ArrayMarshalerFunc(func(arr zapcore.ArrayEncoder) error {
+			for _, msg := range v[k] {
+				arr.AppendString(msg)
+			}
+			return nil
+		})); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler
+func (c counterMetric) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("type", "counter")
+	enc.AddString("name", c.name)
+	enc.AddInt64("delta", c.delta)
+	return nil
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler
+func (g gaugeMetric) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("type", "gauge")
+	enc.AddString("name", g.name)
+	enc.AddFloat64("value", g.value)
+	return nil
+}
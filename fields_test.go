@@ -0,0 +1,244 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLatency(t *testing.T) {
+	enc := zapcore.NewMapObjectEncoder()
+	Latency("latency_ms", 1500*time.Microsecond).AddTo(enc)
+	Latency("zero_ms", 0).AddTo(enc)
+
+	assert.Equal(t, 1.5, enc.Fields["latency_ms"])
+	assert.Equal(t, 0.0, enc.Fields["zero_ms"])
+}
+
+func TestLazy(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(DefaultEncoderConfig), zapcore.AddSync(buf), InfoLevel))
+
+	calls := 0
+	expensive := func() interface{} {
+		calls++
+		return map[string]int{"rows": 3}
+	}
+
+	logger.Debug("suppressed", Lazy("result", expensive))
+	assert.Equal(t, 0, calls)
+	assert.Empty(t, buf.String())
+
+	logger.Info("written", Lazy("result", expensive))
+	assert.Equal(t, 1, calls)
+	assert.Contains(t, buf.String(), `"result":{"rows":3}`)
+}
+
+func TestMetricFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		field zap.Field
+		want  map[string]interface{}
+	}{
+		{
+			name:  "counter",
+			field: Counter("orders_created", 3),
+			want:  map[string]interface{}{"type": "counter", "name": "orders_created", "delta": int64(3)},
+		},
+		{
+			name:  "gauge",
+			field: Gauge("queue_depth", 12.5),
+			want:  map[string]interface{}{"type": "gauge", "name": "queue_depth", "value": 12.5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := zapcore.NewMapObjectEncoder()
+			tt.field.AddTo(enc)
+
+			assert.Equal(t, tt.want, enc.Fields[MetricKey])
+		})
+	}
+}
+
+func TestFields(t *testing.T) {
+	m := map[string]interface{}{
+		"retries":  3,
+		"ratio":    0.5,
+		"enabled":  true,
+		"name":     "billing",
+		"timeout":  2 * time.Second,
+		"err":      errors.New("boom"),
+		"limits":   map[string]interface{}{"cpu": "500m"},
+		"replicas": []int{1, 2},
+	}
+
+	fields := Fields(m)
+	keys := make([]string, 0, len(fields))
+	for _, f := range fields {
+		keys = append(keys, f.Key)
+	}
+	assert.Equal(t, []string{"enabled", "err", "limits", "name", "ratio", "replicas", "retries", "timeout"}, keys)
+
+	types := map[string]zapcore.FieldType{}
+	for _, f := range fields {
+		types[f.Key] = f.Type
+	}
+	assert.Equal(t, zapcore.BoolType, types["enabled"])
+	assert.Equal(t, zapcore.ErrorType, types["err"])
+	assert.Equal(t, zapcore.ReflectType, types["limits"])
+	assert.Equal(t, zapcore.StringType, types["name"])
+	assert.Equal(t, zapcore.Float64Type, types["ratio"])
+	assert.Equal(t, zapcore.ArrayMarshalerType, types["replicas"])
+	assert.Equal(t, zapcore.Int64Type, types["retries"])
+	assert.Equal(t, zapcore.DurationType, types["timeout"])
+}
+
+func TestManager_InfoMap(t *testing.T) {
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger := &Manager{Zap: zap.New(core)}
+	logger.InfoMap(context.Background(), "config loaded", map[string]interface{}{"region": "eu", "workers": 4})
+
+	require.Equal(t, 1, recorded.Len())
+	assert.Equal(t, "config loaded", recorded.All()[0].Message)
+	assert.Equal(t, map[string]interface{}{"region": "eu", "workers": int64(4)}, recorded.All()[0].ContextMap())
+}
+
+func TestBytes(t *testing.T) {
+	data := []byte{0xde, 0xad, 0xbe, 0xef, 0x01}
+
+	tests := []struct {
+		name     string
+		maxLen   int
+		encoding string
+		want     string
+	}{
+		{"hex", 0, "hex", "deadbeef01"},
+		{"hex truncated", 2, "hex", "dead (5 bytes total)"},
+		{"base64", 0, "base64", "3q2+7wE="},
+		{"base64 truncated", 3, "base64", "3q2+ (5 bytes total)"},
+		{"maxLen above length", 5, "hex", "deadbeef01"},
+		{"unknown encoding", 0, "ascii85", "3q2+7wE="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := Bytes("payload", data, tt.maxLen, tt.encoding)
+			assert.Equal(t, "payload", field.Key)
+			assert.Equal(t, tt.want, field.String)
+		})
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	field := ValidationErrors(map[string][]string{
+		"email": {"is required", "must be a valid address"},
+		"age":   {"must be positive"},
+	})
+
+	enc := zapcore.NewMapObjectEncoder()
+	field.AddTo(enc)
+	assert.Equal(t, map[string]interface{}{
+		"age":   []interface{}{"must be positive"},
+		"email": []interface{}{"is required", "must be a valid address"},
+	}, enc.Fields["validation"])
+
+	// Input fields are written in sorted order
+	buf, err := zapcore.NewJSONEncoder(zapcore.EncoderConfig{}).EncodeEntry(zapcore.Entry{}, []zap.Field{field})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"validation":{"age":["must be positive"],"email":["is required","must be a valid address"]}}`, buf.String())
+	assert.Less(t, bytes.Index(buf.Bytes(), []byte(`"age"`)), bytes.Index(buf.Bytes(), []byte(`"email"`)))
+}
+
+func TestTimeRange(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Second)
+
+	enc := zapcore.NewMapObjectEncoder()
+	TimeRange("window", start, end).AddTo(enc)
+	assert.Equal(t, map[string]interface{}{"start": start, "end": end, "duration": 90 * time.Second}, enc.Fields["window"])
+
+	buf, err := zapcore.NewJSONEncoder(DefaultEncoderConfig).EncodeEntry(zapcore.Entry{}, []zap.Field{TimeRange("window", start, end)})
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `"window":{"start":"2024-01-02T03:00:00.000Z","end":"2024-01-02T03:01:30.000Z","duration":90}`)
+}
+
+// orderState is a sample enum for TestEnum
+type orderState int
+
+const (
+	orderPending orderState = iota
+	orderPaid
+	orderShipped
+)
+
+var orderStateNames = map[orderState]string{
+	orderPending: "pending",
+	orderPaid:    "paid",
+	orderShipped: "shipped",
+}
+
+func TestEnum(t *testing.T) {
+	assert.Equal(t, zap.String("state", "paid"), Enum("state", orderPaid, orderStateNames))
+	assert.Equal(t, zap.String("state", "shipped"), Enum("state", orderShipped, orderStateNames))
+	assert.Equal(t, zap.String("state", "7"), Enum("state", orderState(7), orderStateNames))
+	assert.Equal(t, zap.String("state", "-1"), Enum("state", orderState(-1), nil))
+}
+
+func TestStack(t *testing.T) {
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger, err := New(WithExtraCore(core))
+	require.NoError(t, err)
+
+	logger.Info(context.Background(), "how did we get here", Stack("stack"))
+
+	entries := recorded.TakeAll()
+	require.Len(t, entries, 1)
+	assert.Empty(t, entries[0].Stack, "Info is below the stacktrace level")
+	stack, ok := entries[0].ContextMap()["stack"].(string)
+	require.True(t, ok)
+	assert.True(t, strings.HasPrefix(stack, "github.com/sk-pkg/logger.TestStack\n"), "the trace starts at the caller of Stack")
+}
+
+func TestIP(t *testing.T) {
+	tests := []struct {
+		name   string
+		ip     net.IP
+		want   string
+		fields []zap.Field
+	}{
+		{"IPv4", net.ParseIP("192.0.2.1"), "192.0.2.1", []zap.Field{zap.String("ip", "192.0.2.1"), zap.Int("ip_version", 4)}},
+		{"IPv4 4-byte", net.IPv4(192, 0, 2, 1).To4(), "192.0.2.1", []zap.Field{zap.String("ip", "192.0.2.1"), zap.Int("ip_version", 4)}},
+		{"IPv6", net.ParseIP("2001:db8::1"), "2001:db8::1", []zap.Field{zap.String("ip", "2001:db8::1"), zap.Int("ip_version", 6)}},
+		{"nil", nil, "<nil>", []zap.Field{zap.String("ip", "<nil>")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, zap.String("ip", tt.want), IP("ip", tt.ip))
+			assert.Equal(t, tt.fields, IPWithVersion("ip", tt.ip))
+		})
+	}
+}
+
+func TestCIDR(t *testing.T) {
+	_, v4, err := net.ParseCIDR("10.1.2.3/8")
+	require.NoError(t, err)
+	_, v6, err := net.ParseCIDR("2001:db8::/32")
+	require.NoError(t, err)
+
+	assert.Equal(t, zap.String("network", "10.0.0.0/8"), CIDR("network", v4))
+	assert.Equal(t, zap.String("network", "2001:db8::/32"), CIDR("network", v6))
+	assert.Equal(t, zap.String("network", "<nil>"), CIDR("network", nil))
+}
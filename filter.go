@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	// filterFunc reports whether an entry should be written
+	filterFunc func(zapcore.Entry, []zapcore.Field) bool
+
+	// filterCore is a zapcore.Core dropping the entries rejected by a filter
+	filterCore struct {
+		zapcore.Core
+		levelChecked
+		filters []filterFunc
+	}
+)
+
+// WithFilter drops the entries for which the predicate returns false
+//
+// The predicate receives the entry, with its caller resolved, and the fields passed at the
+// call site (fields added through With are not included). It runs before sampling, so
+// dropped entries do not count against sampling budgets. When the option is given several
+// times, an entry is written only if every predicate returns true.
+//
+// Parameters:
+//   - filter: The predicate deciding whether an entry is written
+//
+// Returns:
+//   - Option: A function that adds the filter to the option struct
+func WithFilter(filter func(zapcore.Entry, []zapcore.Field) bool) Option {
+	return func(o *option) {
+		o.filters = append(o.filters, filter)
+	}
+}
+
+// newFilterCore wraps a core to drop the entries rejected by any of the filters
+//
+// Parameters:
+//   - core: The core to wrap
+//   - filters: The predicates an entry must pass
+//
+// Returns:
+//   - zapcore.Core: A core writing only the entries passing every filter
+func newFilterCore(core zapcore.Core, filters []filterFunc) zapcore.Core {
+	return &filterCore{Core: core, filters: filters}
+}
+
+// With adds structured context to the core
+func (c *filterCore) With(fields []zapcore.Field) zapcore.Core {
+	return &filterCore{Core: c.Core.With(fields), filters: c.filters}
+}
+
+// Check defers the decision to Write, where the caller is known
+func (c *filterCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write writes the entry if every filter accepts it
+func (c *filterCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	for _, filter := range c.filters {
+		if !filter(ent, fields) {
+			return nil
+		}
+	}
+
+	return writeEntry(c.Core, ent, fields)
+}
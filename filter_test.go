@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithFilter(t *testing.T) {
+	opt := &option{}
+	WithFilter(func(ent zapcore.Entry, _ []zapcore.Field) bool {
+		return !strings.Contains(ent.Message, "health check")
+	})(opt)
+	WithFilter(func(_ zapcore.Entry, fields []zapcore.Field) bool {
+		for _, f := range fields {
+			if f.Key == "noisy" {
+				return false
+			}
+		}
+		return true
+	})(opt)
+
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger := &Manager{Zap: zap.New(wrapCore(opt, core))}
+
+	ctx := context.Background()
+	logger.Info(ctx, "GET /health check ok")
+	logger.Info(ctx, "cache refreshed", zap.Bool("noisy", true))
+	logger.Info(ctx, "order created")
+	logger.With(ctx, zap.String("component", "billing")).Info("charge captured")
+
+	var messages []string
+	for _, entry := range recorded.All() {
+		messages = append(messages, entry.Message)
+	}
+	assert.Equal(t, []string{"order created", "charge captured"}, messages)
+}
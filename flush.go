@@ -0,0 +1,101 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// flushOnErrorCore is a zapcore.Core syncing its outputs after every Error or more severe entry
+type flushOnErrorCore struct {
+	zapcore.Core
+}
+
+// WithFlushOnError syncs the outputs right after every entry at ErrorLevel or above is written
+//
+// With buffered outputs, an entry explaining a crash could otherwise still sit in a buffer
+// when the process dies. Less severe entries stay buffered, keeping the throughput of normal logs.
+//
+// Returns:
+//   - Option: A function that enables flushing on errors in the option struct
+func WithFlushOnError() Option {
+	return func(o *option) {
+		o.flushOnError = true
+	}
+}
+
+// newFlushOnErrorCore wraps a core to sync it after error entries
+//
+// Parameters:
+//   - core: The core to wrap
+//
+// Returns:
+//   - zapcore.Core: A core syncing after every entry at ErrorLevel or above
+func newFlushOnErrorCore(core zapcore.Core) zapcore.Core {
+	return &flushOnErrorCore{Core: core}
+}
+
+// With adds structured context to the core
+func (c *flushOnErrorCore) With(fields []zapcore.Field) zapcore.Core {
+	return &flushOnErrorCore{Core: c.Core.With(fields)}
+}
+
+// Check adds the core for error entries so Write can sync after them
+func (c *flushOnErrorCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level < zapcore.ErrorLevel {
+		return c.Core.Check(ent, ce)
+	}
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write writes the entry, then syncs the wrapped core
+func (c *flushOnErrorCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if err := writeEntry(c.Core, ent, fields); err != nil {
+		return err
+	}
+
+	return c.Core.Sync()
+}
+
+// checkWrite implements checkedWriter, syncing after error entries only, as Check does
+func (c *flushOnErrorCore) checkWrite(ent zapcore.Entry, fields []zapcore.Field) error {
+	if ent.Level < zapcore.ErrorLevel {
+		return writeEntry(c.Core, ent, fields)
+	}
+	if !c.Enabled(ent.Level) {
+		return nil
+	}
+
+	return c.Write(ent, fields)
+}
+
+// FlushOnContext syncs the outputs once the context is done
+//
+// A request handler buffering its entries can make them durable when the request ends,
+// e.g. with FlushOnContext(r.Context()), and wait on the returned channel where it must be
+// sure the entries were flushed. The wait runs on its own goroutine. For a context that is
+// never done, such as context.Background(), nothing is started and the returned channel is
+// nil, so receiving from it blocks.
+//
+// Parameters:
+//   - ctx: The context whose end triggers the flush
+//
+// Returns:
+//   - <-chan error: A channel receiving the result of Sync once the outputs are flushed
+func (m *Manager) FlushOnContext(ctx context.Context) <-chan error {
+	if ctx == nil || ctx.Done() == nil {
+		return nil
+	}
+
+	flushed := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+		flushed <- m.Sync()
+	}()
+
+	return flushed
+}
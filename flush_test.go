@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// lockedBuffer is a bytes.Buffer safe for the background flushes of a BufferedWriteSyncer
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestWithFlushOnError(t *testing.T) {
+	out := &lockedBuffer{}
+	buffered := &zapcore.BufferedWriteSyncer{WS: zapcore.AddSync(out), Size: 1 << 20, FlushInterval: time.Hour}
+	defer buffered.Stop()
+
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(DefaultEncoderConfig), buffered, DebugLevel)
+	logger := zap.New(wrapCore(&option{flushOnError: true}, core))
+
+	logger.Info("buffered")
+	logger.Warn("still buffered")
+	assert.Empty(t, out.String())
+
+	logger.Error("crash imminent")
+	assert.Contains(t, out.String(), "buffered")
+	assert.Contains(t, out.String(), "crash imminent")
+}
+
+func TestManager_FlushOnContext(t *testing.T) {
+	out := &lockedBuffer{}
+	buffered := &zapcore.BufferedWriteSyncer{WS: zapcore.AddSync(out), Size: 1 << 20, FlushInterval: time.Hour}
+	defer buffered.Stop()
+
+	logger, err := New(WithExtraCore(zapcore.NewCore(zapcore.NewJSONEncoder(DefaultEncoderConfig), buffered, DebugLevel)))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	flushed := logger.FlushOnContext(ctx)
+
+	logger.Info(ctx, "request handled")
+	assert.Empty(t, out.String())
+
+	cancel()
+	select {
+	case <-flushed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("outputs not flushed after the context was cancelled")
+	}
+	assert.Contains(t, out.String(), "request handled")
+}
+
+func TestManager_FlushOnContext_Background(t *testing.T) {
+	logger, err := New()
+	require.NoError(t, err)
+
+	assert.Nil(t, logger.FlushOnContext(context.Background()))
+}
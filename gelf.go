@@ -0,0 +1,284 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// GELFChunkSize is the maximum size of the UDP datagrams sent by NewGELFUDPWriter
+	GELFChunkSize = 1420
+
+	// gelfMaxChunks is the maximum number of chunks of a GELF message
+	gelfMaxChunks = 128
+
+	// gelfChunkHeaderSize is the size of the magic bytes, message ID and sequence of a chunk
+	gelfChunkHeaderSize = 12
+)
+
+// gelfPool is the buffer pool used by the GELF encoder
+var gelfPool = buffer.NewPool()
+
+type (
+	// gelfEncoder is a zapcore.Encoder that emits Graylog Extended Log Format messages
+	//
+	// The fields are accumulated like the MessagePack encoder's, then flattened into
+	// _-prefixed GELF additional fields.
+	gelfEncoder struct {
+		*msgpackEncoder
+		host string
+	}
+
+	// gelfUDPWriter sends GELF messages as UDP datagrams, chunking the large ones
+	gelfUDPWriter struct {
+		conn      net.Conn
+		chunkSize int
+	}
+)
+
+// newGELFEncoder creates a new GELF encoder
+//
+// Parameters:
+//   - cfg: The encoder configuration providing the time and duration encoders
+//
+// Returns:
+//   - zapcore.Encoder: A new GELF encoder
+func newGELFEncoder(cfg zapcore.EncoderConfig) zapcore.Encoder {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	return &gelfEncoder{msgpackEncoder: newMsgpackEncoder(cfg).(*msgpackEncoder), host: host}
+}
+
+// Clone implements zapcore.Encoder
+func (e *gelfEncoder) Clone() zapcore.Encoder {
+	return &gelfEncoder{msgpackEncoder: e.clone(), host: e.host}
+}
+
+// EncodeEntry implements zapcore.Encoder
+func (e *gelfEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	final := e.clone()
+	for _, f := range fields {
+		f.AddTo(final)
+	}
+
+	msg := make(map[string]interface{}, len(final.Fields)+9)
+	e.addFields(msg, "", final.Fields)
+
+	if ent.LoggerName != "" {
+		msg["_logger"] = ent.LoggerName
+	}
+	if ent.Caller.Defined {
+		msg["_caller"] = ent.Caller.TrimmedPath()
+		if ent.Caller.Function != "" {
+			msg["_function"] = ent.Caller.Function
+		}
+	}
+
+	msg["version"] = "1.1"
+	msg["host"] = e.host
+	msg["short_message"] = ent.Message
+	msg["timestamp"] = float64(ent.Time.UnixMicro()) / 1e6
+	msg["level"] = gelfLevel(ent.Level)
+	if ent.Stack != "" {
+		msg["full_message"] = ent.Message + "\n" + ent.Stack
+	}
+
+	line := gelfPool.Get()
+	enc := json.NewEncoder(line)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(msg); err != nil {
+		line.Free()
+		return nil, err
+	}
+
+	return line, nil
+}
+
+// addFields adds the fields to a GELF message as additional fields, flattening nested objects
+//
+// The key of a nested field joins the keys of its parents with '_', e.g. "_user_id".
+func (e *gelfEncoder) addFields(msg map[string]interface{}, prefix string, fields map[string]interface{}) {
+	for k, v := range fields {
+		key := prefix + "_" + gelfKey(k)
+		if nested, ok := v.(map[string]interface{}); ok {
+			e.addFields(msg, key, nested)
+			continue
+		}
+
+		if key == "_id" {
+			key = "__id" // Reserved by Graylog
+		}
+		if value, ok := e.value(v); ok {
+			msg[key] = value
+		}
+	}
+}
+
+// value converts a field value to a string or a number, as GELF expects
+func (e *gelfEncoder) value(v interface{}) (interface{}, bool) {
+	switch v := v.(type) {
+	case nil:
+		return nil, false
+	case string:
+		return v, true
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr:
+		return v, true
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return strconv.FormatFloat(v, 'g', -1, 64), true
+		}
+		return v, true
+	case float32:
+		return e.value(float64(v))
+	case bool:
+		return strconv.FormatBool(v), true
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v), true
+	case time.Time:
+		if e.cfg.EncodeTime == nil {
+			return v.Format(time.RFC3339Nano), true
+		}
+		return encodePrimitive(func(enc zapcore.PrimitiveArrayEncoder) { e.cfg.EncodeTime(v, enc) })
+	case time.Duration:
+		if e.cfg.EncodeDuration == nil {
+			return v.String(), true
+		}
+		return encodePrimitive(func(enc zapcore.PrimitiveArrayEncoder) { e.cfg.EncodeDuration(v, enc) })
+	case complex64, complex128:
+		return fmt.Sprint(v), true
+	}
+
+	// Arrays and reflected values are written as their JSON representation
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v), true
+	}
+
+	return string(encoded), true
+}
+
+// encodePrimitive returns the single value appended by an encoding function
+func encodePrimitive(encode func(zapcore.PrimitiveArrayEncoder)) (interface{}, bool) {
+	enc := zapcore.NewMapObjectEncoder()
+	_ = enc.AddArray("v", zapcore.ArrayMarshalerFunc(func(arr zapcore.ArrayEncoder) error {
+		encode(arr)
+		return nil
+	}))
+	values := enc.Fields["v"].([]interface{})
+	if len(values) == 0 {
+		return nil, false
+	}
+
+	return values[0], true
+}
+
+// gelfKey replaces the characters GELF does not allow in field names by '_'
+func gelfKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '.' || r == '-' || r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+// gelfLevel maps a level to its syslog severity
+//
+// Debug and lower are 7 (debug), Info 6 (informational), Warn 4 (warning), Error 3
+// (error), DPanic 2 (critical), Panic 1 (alert) and Fatal 0 (emergency).
+func gelfLevel(level zapcore.Level) int {
+	switch {
+	case level <= DebugLevel:
+		return 7
+	case level == InfoLevel:
+		return 6
+	case level == WarnLevel:
+		return 4
+	case level == ErrorLevel:
+		return 3
+	case level == DPanicLevel:
+		return 2
+	case level == PanicLevel:
+		return 1
+	}
+
+	return 0
+}
+
+// NewGELFUDPWriter creates a writer sending GELF messages to a Graylog UDP input
+//
+// Used as a sink with the "gelf" encoding, each entry is sent as one datagram, or split into
+// chunks of at most GELFChunkSize bytes following the GELF chunking protocol. Messages
+// needing more than 128 chunks are rejected with an error.
+//
+//	w, err := logger.NewGELFUDPWriter("graylog:12201")
+//	loggerManager, err := logger.New(logger.WithSinkEncoded("gelf", false, w, zapcore.InfoLevel))
+//
+// Parameters:
+//   - addr: The host:port address of the Graylog UDP input
+//
+// Returns:
+//   - io.WriteCloser: A writer sending each written message to Graylog
+//   - error: An error if the address cannot be resolved
+func NewGELFUDPWriter(addr string) (io.WriteCloser, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gelfUDPWriter{conn: conn, chunkSize: GELFChunkSize}, nil
+}
+
+// Write sends a message, in chunks if it does not fit into one datagram
+func (w *gelfUDPWriter) Write(p []byte) (int, error) {
+	msg := bytes.TrimSuffix(p, []byte("\n"))
+	if len(msg) <= w.chunkSize {
+		if _, err := w.conn.Write(msg); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	dataSize := w.chunkSize - gelfChunkHeaderSize
+	count := (len(msg) + dataSize - 1) / dataSize
+	if count > gelfMaxChunks {
+		return 0, fmt.Errorf("gelf message of %d bytes needs more than %d chunks", len(msg), gelfMaxChunks)
+	}
+
+	chunk := make([]byte, 0, w.chunkSize)
+	id := rand.Uint64()
+	for seq := 0; seq < count; seq++ {
+		end := min((seq+1)*dataSize, len(msg))
+		chunk = append(chunk[:0], 0x1e, 0x0f)
+		chunk = binary.BigEndian.AppendUint64(chunk, id)
+		chunk = append(chunk, byte(seq), byte(count))
+		chunk = append(chunk, msg[seq*dataSize:end]...)
+		if _, err := w.conn.Write(chunk); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+// Close closes the UDP socket
+func (w *gelfUDPWriter) Close() error {
+	return w.conn.Close()
+}
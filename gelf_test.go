@@ -0,0 +1,160 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// decodeGELF decodes a GELF message, keeping numbers as json.Number
+func decodeGELF(t *testing.T, line []byte) map[string]interface{} {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	var msg map[string]interface{}
+	require.NoError(t, dec.Decode(&msg))
+
+	return msg
+}
+
+func TestGELFEncoder(t *testing.T) {
+	enc := newGELFEncoder(DefaultEncoderConfig)
+	enc = enc.Clone()
+	enc.AddString("service", "orders")
+
+	ent := zapcore.Entry{
+		Level:      zapcore.ErrorLevel,
+		Time:       time.Unix(1700000000, 250000000),
+		LoggerName: "api",
+		Message:    "payment failed",
+		Caller:     zapcore.NewEntryCaller(0, "/src/api/handler/pay.go", 42, true),
+		Stack:      "main.main\n\t/src/main.go:10",
+	}
+	buf, err := enc.EncodeEntry(ent, []zapcore.Field{
+		zap.Int("amount", 1250),
+		zap.Bool("retry", true),
+		zap.String("id", "p-1"),
+		zap.String("user agent", "curl"),
+		zap.Object("user", zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			enc.AddString("name", "alice")
+			return nil
+		})),
+		zap.Strings("tags", []string{"a", "b"}),
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(buf.String(), "}\n"))
+
+	msg := decodeGELF(t, buf.Bytes())
+	host, _ := os.Hostname()
+	assert.Equal(t, "1.1", msg["version"])
+	assert.Equal(t, host, msg["host"])
+	assert.Equal(t, "payment failed", msg["short_message"])
+	assert.Equal(t, "payment failed\nmain.main\n\t/src/main.go:10", msg["full_message"])
+	assert.Equal(t, json.Number("1700000000.25"), msg["timestamp"])
+	assert.Equal(t, json.Number("3"), msg["level"])
+	assert.Equal(t, "api", msg["_logger"])
+	assert.Equal(t, "handler/pay.go:42", msg["_caller"])
+	assert.Equal(t, "orders", msg["_service"])
+	assert.Equal(t, json.Number("1250"), msg["_amount"])
+	assert.Equal(t, "true", msg["_retry"])
+	assert.Equal(t, "p-1", msg["__id"], "_id is reserved")
+	assert.Equal(t, "curl", msg["_user_agent"])
+	assert.Equal(t, "alice", msg["_user_name"])
+	assert.Equal(t, `["a","b"]`, msg["_tags"])
+}
+
+func TestGELFLevel(t *testing.T) {
+	tests := []struct {
+		level zapcore.Level
+		want  int
+	}{
+		{TraceLevel, 7},
+		{DebugLevel, 7},
+		{InfoLevel, 6},
+		{WarnLevel, 4},
+		{ErrorLevel, 3},
+		{DPanicLevel, 2},
+		{PanicLevel, 1},
+		{FatalLevel, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, gelfLevel(tt.level))
+		})
+	}
+}
+
+func TestWithEncoding_GELF(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger, err := New(WithSinkEncoded("gelf", false, out, zapcore.InfoLevel))
+	require.NoError(t, err)
+	defer logger.Close()
+
+	logger.Warn(context.WithValue(context.Background(), TraceIDKey, "t-1"), "stock low", zap.Int("left", 2))
+
+	msg := decodeGELF(t, out.Bytes())
+	assert.Equal(t, "stock low", msg["short_message"])
+	assert.Equal(t, json.Number("4"), msg["level"])
+	assert.Equal(t, json.Number("2"), msg["_left"])
+	assert.Equal(t, "t-1", msg["_"+TraceIDField])
+}
+
+func TestGELFUDPWriter(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	w, err := NewGELFUDPWriter(conn.LocalAddr().String())
+	require.NoError(t, err)
+	defer w.Close()
+	w.(*gelfUDPWriter).chunkSize = 32
+
+	receive := func() []byte {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		datagram := make([]byte, 64)
+		n, _, err := conn.ReadFrom(datagram)
+		require.NoError(t, err)
+		return datagram[:n]
+	}
+
+	// A small message is sent as is, without the trailing newline
+	n, err := w.Write([]byte("{\"short_message\":\"hi\"}\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 23, n)
+	assert.Equal(t, `{"short_message":"hi"}`, string(receive()))
+
+	// A larger message is chunked
+	msg := `{"short_message":"a message longer than one chunk"}`
+	_, err = w.Write([]byte(msg + "\n"))
+	require.NoError(t, err)
+
+	var reassembled []byte
+	var id uint64
+	for seq := 0; seq < 3; seq++ {
+		chunk := receive()
+		require.Greater(t, len(chunk), gelfChunkHeaderSize)
+		assert.Equal(t, []byte{0x1e, 0x0f}, chunk[:2])
+		if seq == 0 {
+			id = binary.BigEndian.Uint64(chunk[2:10])
+		}
+		assert.Equal(t, id, binary.BigEndian.Uint64(chunk[2:10]), "chunks share the message ID")
+		assert.Equal(t, []byte{byte(seq), 3}, chunk[10:12])
+		reassembled = append(reassembled, chunk[12:]...)
+	}
+	assert.Equal(t, msg, string(reassembled))
+
+	// Messages needing more than 128 chunks are rejected
+	_, err = w.Write(bytes.Repeat([]byte("x"), 20*129))
+	assert.Error(t, err)
+}
@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// goroutineCore is a zapcore.Core adding the logging goroutine's ID to every entry
+type goroutineCore struct {
+	zapcore.Core
+	levelChecked
+}
+
+// WithGoroutineID adds a "goroutine" field holding the ID of the goroutine that logged the entry
+//
+// The ID is parsed from runtime.Stack, which costs roughly a microsecond per entry,
+// so this is meant for debugging concurrency issues rather than always-on production use.
+//
+// Returns:
+//   - Option: A function that enables the goroutine field in the option struct
+func WithGoroutineID() Option {
+	return func(o *option) {
+		o.goroutineID = true
+	}
+}
+
+// newGoroutineCore wraps a core to add the goroutine ID to every entry
+//
+// Parameters:
+//   - core: The core to wrap
+//
+// Returns:
+//   - zapcore.Core: A core adding the "goroutine" field
+func newGoroutineCore(core zapcore.Core) zapcore.Core {
+	return &goroutineCore{Core: core}
+}
+
+// With adds structured context to the core
+func (c *goroutineCore) With(fields []zapcore.Field) zapcore.Core {
+	return &goroutineCore{Core: c.Core.With(fields)}
+}
+
+// Check adds the core so Write runs on the logging goroutine
+func (c *goroutineCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write writes the entry with the goroutine field prepended, outside any namespace opened by the fields
+func (c *goroutineCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	all := make([]zapcore.Field, 0, len(fields)+1)
+	all = append(all, zap.Uint64("goroutine", goroutineID()))
+	all = append(all, fields...)
+
+	return writeEntry(c.Core, ent, all)
+}
+
+// goroutineID returns the ID of the current goroutine, or 0 if it cannot be parsed
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+
+	// The stack starts with "goroutine <id> [<state>]:"
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+
+	id, err := strconv.ParseUint(string(b), 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return id
+}
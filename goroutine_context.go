@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"context"
+	"sync"
+)
+
+// goroutineContexts holds the contexts set with SetContext, keyed by goroutine ID
+var goroutineContexts sync.Map
+
+// SetContext associates a context with the current goroutine until the returned function is called
+//
+// This is an escape hatch for legacy code where threading a context through deep call stacks
+// is impractical; prefer passing the context explicitly. Goroutine-local state is discouraged
+// in Go for good reasons:
+//   - The context is not inherited by goroutines started from the current one; each
+//     goroutine must call SetContext itself.
+//   - The association lives until the returned function is called, so always
+//     defer it, or the entry leaks for the lifetime of the process.
+//   - Finding the goroutine ID parses runtime.Stack, which costs about a microsecond.
+//
+// The context is returned by CurrentContext, and managers created with WithGoroutineContext
+// fall back to it when the context passed to a log call carries no TraceID or RequestID.
+//
+// Parameters:
+//   - ctx: The context of the current goroutine
+//
+// Returns:
+//   - func(): A function removing the association, to be deferred
+func SetContext(ctx context.Context) func() {
+	id := goroutineID()
+	goroutineContexts.Store(id, ctx)
+
+	return func() {
+		goroutineContexts.CompareAndDelete(id, ctx)
+	}
+}
+
+// CurrentContext returns the context set with SetContext on the current goroutine
+//
+// Returns:
+//   - context.Context: The goroutine's context, context.Background() if none is set
+func CurrentContext() context.Context {
+	if ctx, ok := goroutineContexts.Load(goroutineID()); ok {
+		return ctx.(context.Context)
+	}
+
+	return context.Background()
+}
+
+// WithGoroutineContext makes the manager fall back to the context set with SetContext
+//
+// When the context passed to a log call carries no TraceID or RequestID, the context of
+// the current goroutine is consulted before the base context. This is opt-in because it
+// looks up the goroutine ID on every log call; see SetContext for the caveats.
+//
+// Returns:
+//   - Option: A function that enables the goroutine context in the option struct
+func WithGoroutineContext() Option {
+	return func(o *option) {
+		o.goroutineContext = true
+	}
+}
+
+// fallbackContexts returns the contexts consulted when the per-call context lacks a value
+//
+// Returns:
+//   - []context.Context: The goroutine's context if enabled, then the base context
+func (m *Manager) fallbackContexts() []context.Context {
+	var contexts []context.Context
+	if opt := m.options(); opt != nil && opt.goroutineContext {
+		if ctx, ok := goroutineContexts.Load(goroutineID()); ok {
+			contexts = append(contexts, ctx.(context.Context))
+		}
+	}
+	if m.baseCtx != nil {
+		contexts = append(contexts, m.baseCtx)
+	}
+
+	return contexts
+}
@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSetContext(t *testing.T) {
+	assert.Equal(t, context.Background(), CurrentContext())
+
+	ctx := context.WithValue(context.Background(), TraceIDKey, "main")
+	unset := SetContext(ctx)
+	assert.Equal(t, ctx, CurrentContext())
+
+	var wg sync.WaitGroup
+	got := make([]string, 3)
+	for i := range got {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.Equal(t, context.Background(), CurrentContext(), "not inherited by new goroutines")
+
+			own := context.WithValue(context.Background(), TraceIDKey, string(rune('a'+i)))
+			defer SetContext(own)()
+			got[i] = getTraceIDFromContext(CurrentContext())
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, []string{"a", "b", "c"}, got)
+	assert.Equal(t, ctx, CurrentContext())
+
+	unset()
+	assert.Equal(t, context.Background(), CurrentContext())
+}
+
+func TestWithGoroutineContext(t *testing.T) {
+	opt := &option{}
+	WithGoroutineContext()(opt)
+
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger := &Manager{
+		Zap:     zap.New(core),
+		baseCtx: context.WithValue(context.Background(), RequestIDKey, "base-request"),
+		opt:     opt,
+	}
+
+	defer SetContext(context.WithValue(context.Background(), TraceIDKey, "goroutine-trace"))()
+	logger.Info(context.Background(), "from goroutine")
+	logger.Info(context.WithValue(context.Background(), TraceIDKey, "call-trace"), "from call")
+
+	require.Equal(t, 2, recorded.Len())
+	assert.Equal(t, map[string]interface{}{TraceIDField: "goroutine-trace", RequestIDField: "base-request"}, recorded.All()[0].ContextMap())
+	assert.Equal(t, "call-trace", recorded.All()[1].ContextMap()[TraceIDField])
+
+	disabled := &Manager{Zap: zap.New(core)}
+	disabled.Info(context.Background(), "disabled")
+	assert.Empty(t, recorded.All()[2].ContextMap())
+}
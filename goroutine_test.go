@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithGoroutineID(t *testing.T) {
+	const workers = 5
+
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger := &Manager{Zap: zap.New(wrapCore(&option{goroutineID: true}, core))}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			logger.Info(context.Background(), "working", zap.Int("worker", worker))
+			logger.Info(context.Background(), "done", zap.Int("worker", worker))
+		}(i)
+	}
+	wg.Wait()
+
+	ids := make(map[int64]uint64)
+	for _, entry := range recorded.All() {
+		fields := entry.ContextMap()
+		worker := fields["worker"].(int64)
+		id, ok := fields["goroutine"].(uint64)
+		require.True(t, ok)
+		require.NotZero(t, id)
+
+		if prev, seen := ids[worker]; seen {
+			assert.Equal(t, prev, id, "entries of one goroutine share an ID")
+		}
+		ids[worker] = id
+	}
+
+	distinct := make(map[uint64]struct{})
+	for _, id := range ids {
+		distinct[id] = struct{}{}
+	}
+	assert.Len(t, distinct, workers)
+}
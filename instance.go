@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// InstanceIDField is the key of the field holding the instance ID
+const InstanceIDField = "instance_id"
+
+// WithInstanceID adds an ID identifying the running process to every entry
+//
+// A random UUID is generated once by New and added to every entry as "instance_id", which
+// tells apart the entries of many instances of the same service in aggregated views. A
+// restarted process gets a new ID. The ID is kept by Reconfigure and returned by InstanceID.
+//
+// Returns:
+//   - Option: A function that enables the instance ID in the option struct
+func WithInstanceID() Option {
+	return func(o *option) {
+		o.instanceID = newInstanceID()
+	}
+}
+
+// InstanceID returns the ID added to every entry by WithInstanceID
+//
+// Returns:
+//   - string: The instance UUID, empty when WithInstanceID is not used
+func (m *Manager) InstanceID() string {
+	if m.opt == nil {
+		return ""
+	}
+
+	return m.opt.instanceID
+}
+
+// newInstanceID returns a random version 4 UUID
+func newInstanceID() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	id[6] = id[6]&0x0f | 0x40 // Version 4
+	id[8] = id[8]&0x3f | 0x80 // RFC 4122 variant
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:16])
+}
@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithInstanceID(t *testing.T) {
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger, err := New(WithInstanceID(), WithExtraCore(core))
+	require.NoError(t, err)
+
+	id := logger.InstanceID()
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), id)
+
+	ctx := context.Background()
+	logger.Info(ctx, "order created")
+	logger.Warn(ctx, "stock low")
+	logger.With(ctx).Info("order paid")
+
+	entries := recorded.TakeAll()
+	require.Len(t, entries, 3)
+	for _, entry := range entries {
+		assert.Equal(t, id, entry.ContextMap()[InstanceIDField])
+	}
+
+	other, err := New(WithInstanceID())
+	require.NoError(t, err)
+	assert.NotEqual(t, id, other.InstanceID(), "each process instance gets its own ID")
+}
+
+func TestManager_InstanceID_Disabled(t *testing.T) {
+	logger, err := New()
+	require.NoError(t, err)
+	assert.Empty(t, logger.InstanceID())
+}
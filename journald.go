@@ -0,0 +1,15 @@
+package logger
+
+// WithJournald sends entries to the systemd journal
+//
+// The "journald" driver is only available on Linux. It speaks the journal's native protocol,
+// so each zap field becomes a queryable journal field (upper-cased, with characters outside
+// A-Z, 0-9 and _ replaced by _) and the level is mapped to the syslog PRIORITY.
+//
+// Returns:
+//   - Option: A function that selects the "journald" driver in the option struct
+func WithJournald() Option {
+	return func(o *option) {
+		o.driver = "journald"
+	}
+}
@@ -0,0 +1,234 @@
+//go:build linux
+
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// journalSocket is the path of the journal's native protocol socket
+var journalSocket = "/run/systemd/journal/socket"
+
+// journaldCore is a zapcore.Core sending entries to the systemd journal
+type journaldCore struct {
+	zapcore.LevelEnabler
+	levelChecked
+	conn       *net.UnixConn
+	identifier string          // SYSLOG_IDENTIFIER of every entry
+	context    []zapcore.Field // Fields added with With
+}
+
+// newJournaldCore creates a core sending entries to the systemd journal
+//
+// Parameters:
+//   - opt: The option struct containing configuration
+//   - level: The zap.AtomicLevel for dynamic level changes
+//
+// Returns:
+//   - zapcore.Core: A new Core for the systemd journal
+//   - error: An error if the journal socket cannot be reached
+func newJournaldCore(opt *option, level zap.AtomicLevel) (zapcore.Core, error) {
+	if opt.journalConn == nil {
+		conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: journalSocket, Net: "unixgram"})
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to journal socket: %w", err)
+		}
+		opt.journalConn = conn
+		opt.closers = append(opt.closers, conn.Close)
+	}
+
+	return &journaldCore{
+		LevelEnabler: level,
+		conn:         opt.journalConn,
+		identifier:   filepath.Base(os.Args[0]),
+	}, nil
+}
+
+// With adds structured context to the core
+func (c *journaldCore) With(fields []zapcore.Field) zapcore.Core {
+	context := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	context = append(context, c.context...)
+	context = append(context, fields...)
+
+	return &journaldCore{LevelEnabler: c.LevelEnabler, conn: c.conn, identifier: c.identifier, context: context}
+}
+
+// Check adds the core if the entry's level is enabled
+func (c *journaldCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+
+	return ce
+}
+
+// Write sends the entry and its fields to the journal
+func (c *journaldCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.context {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	var buf bytes.Buffer
+	appendJournalVar(&buf, "MESSAGE", ent.Message)
+	appendJournalVar(&buf, "PRIORITY", strconv.Itoa(journalPriority(ent.Level)))
+	appendJournalVar(&buf, "SYSLOG_IDENTIFIER", c.identifier)
+	if ent.LoggerName != "" {
+		appendJournalVar(&buf, "LOGGER", ent.LoggerName)
+	}
+	if ent.Caller.Defined {
+		appendJournalVar(&buf, "CODE_FILE", ent.Caller.File)
+		appendJournalVar(&buf, "CODE_LINE", strconv.Itoa(ent.Caller.Line))
+		if ent.Caller.Function != "" {
+			appendJournalVar(&buf, "CODE_FUNC", ent.Caller.Function)
+		}
+	}
+	if ent.Stack != "" {
+		appendJournalVar(&buf, "STACKTRACE", ent.Stack)
+	}
+	for key, value := range enc.Fields {
+		if name := journalFieldName(key); name != "" {
+			appendJournalVar(&buf, name, journalValue(value))
+		}
+	}
+
+	return c.send(buf.Bytes())
+}
+
+// Sync is a no-op, entries are sent synchronously
+func (c *journaldCore) Sync() error {
+	return nil
+}
+
+// send writes a datagram to the journal, passing it as a file descriptor if it is too large
+func (c *journaldCore) send(data []byte) error {
+	_, err := c.conn.Write(data)
+	if err == nil || !(errors.Is(err, syscall.EMSGSIZE) || errors.Is(err, syscall.ENOBUFS)) {
+		return err
+	}
+
+	f, err := os.CreateTemp("/dev/shm", "journal.*")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err = os.Remove(f.Name()); err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err != nil {
+		return err
+	}
+
+	_, _, err = c.conn.WriteMsgUnix(nil, syscall.UnixRights(int(f.Fd())), nil)
+	return err
+}
+
+// journalPriority maps a level to its syslog priority
+func journalPriority(level zapcore.Level) int {
+	switch {
+	case level <= zapcore.DebugLevel:
+		return 7 // LOG_DEBUG, also used for TraceLevel
+	case level == zapcore.InfoLevel:
+		return 6 // LOG_INFO
+	case level == zapcore.WarnLevel:
+		return 4 // LOG_WARNING
+	case level == zapcore.ErrorLevel:
+		return 3 // LOG_ERR
+	default:
+		return 2 // LOG_CRIT
+	}
+}
+
+// journalFieldName converts a zap field key into a valid journal field name
+//
+// Journal field names consist of upper-case letters, digits and underscores, must not
+// start with an underscore (reserved for trusted fields) and are at most 64 bytes long.
+// Names the driver writes itself or journald interprets, e.g. PRIORITY or CODE_FILE, are
+// prefixed with "F_" so a field cannot add a second value next to the entry's own.
+func journalFieldName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		}
+		return '_'
+	}, key)
+
+	name = strings.TrimLeft(name, "_")
+	if name != "" && (name[0] >= '0' && name[0] <= '9' || isReservedJournalName(name)) {
+		name = "F_" + name
+	}
+	if len(name) > 64 {
+		name = name[:64]
+	}
+
+	return name
+}
+
+// isReservedJournalName reports whether a field name is written by the driver or interpreted by journald
+func isReservedJournalName(name string) bool {
+	switch name {
+	case "MESSAGE", "PRIORITY", "LOGGER", "STACKTRACE":
+		return true
+	}
+
+	return strings.HasPrefix(name, "SYSLOG_") || strings.HasPrefix(name, "CODE_")
+}
+
+// journalValue renders a value produced by zapcore.MapObjectEncoder as a string
+func journalValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case time.Duration:
+		return v.String()
+	case map[string]interface{}, []interface{}:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	}
+
+	return fmt.Sprint(value)
+}
+
+// appendJournalVar appends a variable in the journal's native protocol format
+//
+// Values containing a newline are written as the name, a newline, the value's
+// little-endian 64-bit length and the raw value.
+func appendJournalVar(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name)
+	if !strings.Contains(value, "\n") {
+		buf.WriteByte('=')
+	} else {
+		buf.WriteByte('\n')
+		_ = binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	}
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
@@ -0,0 +1,173 @@
+//go:build linux
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// parseJournalVars decodes a datagram in the journal's native protocol format
+func parseJournalVars(t *testing.T, data []byte) map[string]string {
+	vars := make(map[string]string)
+	for len(data) > 0 {
+		line := bytes.IndexByte(data, '\n')
+		require.GreaterOrEqual(t, line, 0)
+
+		if eq := bytes.IndexByte(data[:line], '='); eq >= 0 {
+			vars[string(data[:eq])] = string(data[eq+1 : line])
+			data = data[line+1:]
+			continue
+		}
+
+		name := string(data[:line])
+		data = data[line+1:]
+		size := binary.LittleEndian.Uint64(data[:8])
+		vars[name] = string(data[8 : 8+size])
+		data = data[8+size+1:]
+	}
+
+	return vars
+}
+
+func TestNew_Journald_SetEncoding(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "socket")
+	listener, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	require.NoError(t, err)
+	defer listener.Close()
+
+	original := journalSocket
+	journalSocket = socket
+	defer func() { journalSocket = original }()
+
+	logger, err := New(WithJournald())
+	require.NoError(t, err)
+	defer logger.Close()
+
+	conn := logger.opt.journalConn
+	require.NotNil(t, conn)
+	require.NoError(t, logger.SetEncoding("console"))
+	require.NoError(t, logger.SetEncoding("json"))
+	assert.Same(t, conn, logger.opt.journalConn, "the connection is reused")
+	assert.Len(t, logger.opt.closers, 1)
+
+	logger.Info(context.Background(), "after switch")
+	buf := make([]byte, 4096)
+	n, err := listener.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "after switch", parseJournalVars(t, buf[:n])["MESSAGE"])
+}
+
+func TestNew_Journald(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "socket")
+	listener, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	require.NoError(t, err)
+	defer listener.Close()
+
+	original := journalSocket
+	journalSocket = socket
+	defer func() { journalSocket = original }()
+
+	logger, err := New(WithJournald())
+	require.NoError(t, err)
+	defer logger.Close()
+
+	ctx := context.WithValue(context.Background(), TraceIDKey, "trace-1")
+	logger.Warn(ctx, "charge declined",
+		zap.String("service", "billing"),
+		zap.Int("user.id", 42),
+		zap.String("detail", "line one\nline two"),
+		zap.Strings("tags", []string{"a", "b"}),
+	)
+
+	buf := make([]byte, 4096)
+	n, err := listener.Read(buf)
+	require.NoError(t, err)
+
+	vars := parseJournalVars(t, buf[:n])
+	assert.Equal(t, "charge declined", vars["MESSAGE"])
+	assert.Equal(t, "4", vars["PRIORITY"])
+	assert.Equal(t, filepath.Base(os.Args[0]), vars["SYSLOG_IDENTIFIER"])
+	assert.Equal(t, "billing", vars["SERVICE"])
+	assert.Equal(t, "42", vars["USER_ID"])
+	assert.Equal(t, "trace-1", vars["TRACEID"])
+	assert.Equal(t, "line one\nline two", vars["DETAIL"])
+	assert.Equal(t, `["a","b"]`, vars["TAGS"])
+	assert.Contains(t, vars["CODE_FILE"], "journald_linux_test.go")
+}
+
+func TestNew_Journald_ReservedFields(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "socket")
+	listener, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	require.NoError(t, err)
+	defer listener.Close()
+
+	original := journalSocket
+	journalSocket = socket
+	defer func() { journalSocket = original }()
+
+	logger, err := New(WithJournald())
+	require.NoError(t, err)
+	defer logger.Close()
+
+	logger.Error(context.Background(), "charge declined", zap.String("priority", "x"), zap.String("message", "y"))
+
+	buf := make([]byte, 16384)
+	n, err := listener.Read(buf)
+	require.NoError(t, err)
+
+	assert.Len(t, regexp.MustCompile(`(?m)^PRIORITY=`).FindAll(buf[:n], -1), 1)
+	assert.Len(t, regexp.MustCompile(`(?m)^MESSAGE=`).FindAll(buf[:n], -1), 1)
+
+	vars := parseJournalVars(t, buf[:n])
+	assert.Equal(t, "3", vars["PRIORITY"])
+	assert.Equal(t, "charge declined", vars["MESSAGE"])
+	assert.Equal(t, "x", vars["F_PRIORITY"])
+	assert.Equal(t, "y", vars["F_MESSAGE"])
+}
+
+func TestNew_JournaldAvailable(t *testing.T) {
+	if _, err := os.Stat(journalSocket); err != nil {
+		t.Skip("journald is not available")
+	}
+
+	logger, err := New(WithJournald())
+	require.NoError(t, err)
+	defer logger.Close()
+
+	logger.Info(context.Background(), "journald test entry", zap.String("test", t.Name()))
+}
+
+func TestJournalFieldName(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"user_id", "USER_ID"},
+		{"http.status", "HTTP_STATUS"},
+		{"_private", "PRIVATE"},
+		{"2fa", "F_2FA"},
+		{"priority", "F_PRIORITY"},
+		{"message", "F_MESSAGE"},
+		{"syslog_identifier", "F_SYSLOG_IDENTIFIER"},
+		{"code_file", "F_CODE_FILE"},
+		{"message_id", "MESSAGE_ID"},
+		{"___", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			assert.Equal(t, tt.want, journalFieldName(tt.key))
+		})
+	}
+}
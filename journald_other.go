@@ -0,0 +1,15 @@
+//go:build !linux
+
+package logger
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// newJournaldCore reports that the systemd journal is not available on this platform
+func newJournaldCore(_ *option, _ zap.AtomicLevel) (zapcore.Core, error) {
+	return nil, errors.New("journald driver is only supported on linux")
+}
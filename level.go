@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	// levelStack tracks the scopes opened by PushLevel
+	levelStack struct {
+		mu     sync.Mutex
+		base   zapcore.Level // Level active before the first open scope
+		scopes []*levelScope // Open scopes, the last one sets the level
+	}
+
+	// levelScope is a level set by PushLevel until it is restored
+	levelScope struct {
+		level zapcore.Level
+	}
+)
+
+// PushLevel sets the level until the returned function is called
+//
+// Scopes nest with stack semantics: restoring a scope re-applies the level of the most
+// recent scope still open, and restoring the last open scope returns to the level that
+// was active before the first push. Scopes may be restored in any order and the restore
+// function may be called more than once. An invalid level is rejected like in SetLevel and
+// the returned function does nothing.
+//
+// Parameters:
+//   - level: The level to set
+//
+// Returns:
+//   - func(): A function restoring the previous level
+func (m *Manager) PushLevel(level zapcore.Level) func() {
+	if level < TraceLevel || level > FatalLevel {
+		m.Zap.Warn("ignoring invalid log level", zap.Int8("level", int8(level)))
+		return func() {}
+	}
+
+	stack := &m.opt.levelStack
+	stack.mu.Lock()
+	defer stack.mu.Unlock()
+
+	if len(stack.scopes) == 0 {
+		stack.base = m.level.Level()
+	}
+	scope := &levelScope{level: level}
+	stack.scopes = append(stack.scopes, scope)
+	m.level.SetLevel(level)
+
+	return func() {
+		stack.mu.Lock()
+		defer stack.mu.Unlock()
+
+		for i, s := range stack.scopes {
+			if s != scope {
+				continue
+			}
+
+			stack.scopes = append(stack.scopes[:i], stack.scopes[i+1:]...)
+			if len(stack.scopes) == 0 {
+				m.level.SetLevel(stack.base)
+			} else {
+				m.level.SetLevel(stack.scopes[len(stack.scopes)-1].level)
+			}
+			return
+		}
+	}
+}
+
+// levelName returns the lower-case name of a level, as accepted by WithLevel
+func levelName(level zapcore.Level) string {
+	if level == TraceLevel {
+		return "trace"
+	}
+
+	return level.String()
+}
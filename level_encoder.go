@@ -0,0 +1,148 @@
+package logger
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DefaultNumericLevels is the numeric level written by WithNumericLevel when no mapping is given
+var DefaultNumericLevels = map[zapcore.Level]int{
+	TraceLevel:          5,
+	zapcore.DebugLevel:  10,
+	zapcore.InfoLevel:   20,
+	zapcore.WarnLevel:   30,
+	zapcore.ErrorLevel:  40,
+	zapcore.DPanicLevel: 50,
+	zapcore.PanicLevel:  60,
+	zapcore.FatalLevel:  70,
+}
+
+// numericLevelCore is a zapcore.Core adding the numeric level to every entry
+type numericLevelCore struct {
+	zapcore.Core
+	levelChecked
+	key    string
+	levels map[zapcore.Level]int
+}
+
+// WithLevelStrings sets the strings written for the given levels
+//
+// Levels missing from the map keep the encoder config's level encoding, e.g.
+// map[zapcore.Level]string{zapcore.WarnLevel: "WARNING"} only changes warnings.
+// Custom levels below DebugLevel can be named as well.
+//
+// Parameters:
+//   - names: The string written for each level
+//
+// Returns:
+//   - Option: A function that sets the level strings in the option struct
+func WithLevelStrings(names map[zapcore.Level]string) Option {
+	return func(o *option) {
+		o.levelStrings = make(map[zapcore.Level]string, len(names))
+		for level, name := range names {
+			o.levelStrings[level] = name
+		}
+	}
+}
+
+// levelEncoder returns the level encoder resolved from the encoder config and level strings
+//
+// TraceLevel is unknown to zap, which would render it as "LEVEL(-2)", so it is written as
+// "TRACE" unless the level strings name it differently.
+//
+// Parameters:
+//   - opt: The option struct containing configuration
+//
+// Returns:
+//   - zapcore.LevelEncoder: The level encoder to use, nil if neither is set
+func levelEncoder(opt *option) zapcore.LevelEncoder {
+	encode := opt.encoderConfig.EncodeLevel
+	if encode == nil {
+		if len(opt.levelStrings) == 0 {
+			return nil
+		}
+		encode = zapcore.CapitalLevelEncoder
+	}
+
+	names := map[zapcore.Level]string{TraceLevel: "TRACE"}
+	for level, name := range opt.levelStrings {
+		names[level] = name
+	}
+
+	return func(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+		if name, ok := names[level]; ok {
+			enc.AppendString(name)
+			return
+		}
+
+		encode(level, enc)
+	}
+}
+
+// WithNumericLevel adds the level as a number to every entry, next to the textual level
+//
+// Systems filtering by numeric severity can compare the field, e.g. "level_num >= 30".
+// Levels missing from mapping use DefaultNumericLevels.
+//
+// Parameters:
+//   - key: The key of the numeric level field
+//   - mapping: The number written for each level, nil for DefaultNumericLevels
+//
+// Returns:
+//   - Option: A function that sets the numeric level in the option struct
+func WithNumericLevel(key string, mapping map[zapcore.Level]int) Option {
+	return func(o *option) {
+		levels := make(map[zapcore.Level]int, len(DefaultNumericLevels))
+		for level, n := range DefaultNumericLevels {
+			levels[level] = n
+		}
+		for level, n := range mapping {
+			levels[level] = n
+		}
+
+		o.numericLevelKey = key
+		o.numericLevels = levels
+	}
+}
+
+// newNumericLevelCore wraps a core to add the numeric level to every entry
+//
+// Parameters:
+//   - core: The core to wrap
+//   - key: The key of the numeric level field
+//   - levels: The number written for each level
+//
+// Returns:
+//   - zapcore.Core: A core adding the numeric level field
+func newNumericLevelCore(core zapcore.Core, key string, levels map[zapcore.Level]int) zapcore.Core {
+	return &numericLevelCore{Core: core, key: key, levels: levels}
+}
+
+// With adds structured context to the core
+func (c *numericLevelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &numericLevelCore{Core: c.Core.With(fields), key: c.key, levels: c.levels}
+}
+
+// Check adds the core so Write can add the numeric level
+func (c *numericLevelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write writes the entry with the numeric level prepended
+func (c *numericLevelCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	n, ok := c.levels[ent.Level]
+	if !ok {
+		return writeEntry(c.Core, ent, fields)
+	}
+
+	// Prepend the field so it stays outside any namespace opened by the fields
+	all := make([]zapcore.Field, 0, len(fields)+1)
+	all = append(all, zap.Int(c.key, n))
+	all = append(all, fields...)
+
+	return writeEntry(c.Core, ent, all)
+}
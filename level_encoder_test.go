@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithLevelStrings(t *testing.T) {
+	const traceLevel = zapcore.DebugLevel - 1
+
+	opt := &option{encoderConfig: DefaultEncoderConfig}
+	WithLevelStrings(map[zapcore.Level]string{
+		zapcore.WarnLevel: "WARNING",
+		traceLevel:        "TRACE",
+	})(opt)
+
+	config := DefaultEncoderConfig
+	config.EncodeLevel = levelEncoder(opt)
+	encoder := zapcore.NewJSONEncoder(config)
+
+	tests := []struct {
+		level zapcore.Level
+		want  string
+	}{
+		{zapcore.WarnLevel, "WARNING"},
+		{traceLevel, "TRACE"},
+		{zapcore.InfoLevel, "INFO"},
+		{zapcore.ErrorLevel, "ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			buf, err := encoder.EncodeEntry(zapcore.Entry{Level: tt.level, Message: "level"}, nil)
+			require.NoError(t, err)
+			defer buf.Free()
+
+			var got map[string]interface{}
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+			assert.Equal(t, tt.want, got["L"])
+		})
+	}
+}
+
+func TestWithNumericLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping map[zapcore.Level]int
+		level   zapcore.Level
+		want    float64
+	}{
+		{"default info", nil, zapcore.InfoLevel, 20},
+		{"default error", nil, zapcore.ErrorLevel, 40},
+		{"custom warn", map[zapcore.Level]int{zapcore.WarnLevel: 13}, zapcore.WarnLevel, 13},
+		{"custom falls back to default", map[zapcore.Level]int{zapcore.WarnLevel: 13}, zapcore.DebugLevel, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opt := &option{}
+			WithNumericLevel("level_num", tt.mapping)(opt)
+
+			out := &bytes.Buffer{}
+			core := zapcore.NewCore(zapcore.NewJSONEncoder(DefaultEncoderConfig), zapcore.AddSync(out), zapcore.DebugLevel)
+			zap.New(wrapCore(opt, core)).Log(tt.level, "numeric", zap.Namespace("details"))
+
+			var got map[string]interface{}
+			require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+			assert.Equal(t, tt.want, got["level_num"])
+			assert.Equal(t, strings.ToUpper(tt.level.String()), got["L"])
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package logger
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	// levelField is a field only written while its level is enabled
+	levelField struct {
+		minLevel zapcore.Level
+		field    zap.Field
+	}
+
+	// levelFieldCore is a zapcore.Core resolving the fields created by AtLevel
+	levelFieldCore struct {
+		zapcore.Core
+		levelChecked
+		context []levelField // AtLevel fields added through With, resolved at write time
+	}
+)
+
+// AtLevel constructs a field that is only written while minLevel is enabled
+//
+// Verbose diagnostics, e.g. the full request headers, can be attached to every entry
+// with AtLevel(zapcore.DebugLevel, field): they are written while the logger runs at
+// Debug and hidden once it runs at Info, whatever the level of the entry itself. The
+// decision follows SetLevel and PushLevel at write time, also for fields added through
+// With. Outside of the cores created by New, the field is never written.
+//
+// Parameters:
+//   - minLevel: The level that must be enabled for the field to be written
+//   - field: The wrapped field
+//
+// Returns:
+//   - zap.Field: A field written only while minLevel is enabled
+func AtLevel(minLevel zapcore.Level, field zap.Field) zap.Field {
+	return zap.Field{Key: field.Key, Type: zapcore.InlineMarshalerType, Interface: levelField{minLevel: minLevel, field: field}}
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, writing nothing outside a levelFieldCore
+func (f levelField) MarshalLogObject(zapcore.ObjectEncoder) error {
+	return nil
+}
+
+// newLevelFieldCore wraps a core to resolve the fields created by AtLevel
+//
+// Parameters:
+//   - core: The core to wrap
+//
+// Returns:
+//   - zapcore.Core: A core writing AtLevel fields while their level is enabled
+func newLevelFieldCore(core zapcore.Core) zapcore.Core {
+	return &levelFieldCore{Core: core}
+}
+
+// With adds structured context to the core, keeping the AtLevel fields for write time
+func (c *levelFieldCore) With(fields []zapcore.Field) zapcore.Core {
+	context := c.context
+	plain := make([]zapcore.Field, 0, len(fields))
+	for _, f := range fields {
+		if lf, ok := asLevelField(f); ok {
+			context = append(context[:len(context):len(context)], lf)
+			continue
+		}
+		plain = append(plain, f)
+	}
+
+	return &levelFieldCore{Core: c.Core.With(plain), context: context}
+}
+
+// Check adds the core so Write can resolve the fields
+func (c *levelFieldCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write replaces the AtLevel fields by their wrapped field or drops them, depending on their level
+func (c *levelFieldCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if len(c.context) == 0 && !hasLevelField(fields) {
+		return writeEntry(c.Core, ent, fields)
+	}
+
+	resolved := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	for _, lf := range c.context {
+		if c.Enabled(lf.minLevel) {
+			resolved = append(resolved, lf.field)
+		}
+	}
+	for _, f := range fields {
+		lf, ok := asLevelField(f)
+		switch {
+		case !ok:
+			resolved = append(resolved, f)
+		case c.Enabled(lf.minLevel):
+			resolved = append(resolved, lf.field)
+		}
+	}
+
+	return writeEntry(c.Core, ent, resolved)
+}
+
+// asLevelField returns the levelField of a field created by AtLevel
+func asLevelField(f zapcore.Field) (levelField, bool) {
+	if f.Type != zapcore.InlineMarshalerType {
+		return levelField{}, false
+	}
+	lf, ok := f.Interface.(levelField)
+
+	return lf, ok
+}
+
+// hasLevelField reports whether any of the fields was created by AtLevel
+func hasLevelField(fields []zapcore.Field) bool {
+	for _, f := range fields {
+		if _, ok := asLevelField(f); ok {
+			return true
+		}
+	}
+
+	return false
+}
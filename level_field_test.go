@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestAtLevel(t *testing.T) {
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	obs, recorded := observer.New(level)
+	logger := &Manager{Zap: zap.New(wrapCore(&option{}, obs)), level: level}
+	headers := AtLevel(zapcore.DebugLevel, zap.String("headers", "Accept: */*"))
+	derived := &Manager{Zap: logger.With(context.Background(), AtLevel(zapcore.DebugLevel, zap.Int("body_size", 42)))}
+
+	logger.Info(context.Background(), "production", headers, zap.String("path", "/"))
+	derived.Info(context.Background(), "derived production")
+	logger.SetLevel(zapcore.DebugLevel)
+	logger.Info(context.Background(), "debugging", headers, zap.String("path", "/"))
+	derived.Info(context.Background(), "derived debugging")
+
+	entries := recorded.All()
+	require.Len(t, entries, 4)
+	assert.Equal(t, map[string]interface{}{"path": "/"}, entries[0].ContextMap())
+	assert.Empty(t, entries[1].ContextMap())
+	assert.Equal(t, map[string]interface{}{"headers": "Accept: */*", "path": "/"}, entries[2].ContextMap())
+	assert.Equal(t, map[string]interface{}{"body_size": int64(42)}, entries[3].ContextMap())
+}
+
+func TestAtLevel_WithoutCore(t *testing.T) {
+	enc := zapcore.NewMapObjectEncoder()
+	AtLevel(zapcore.DebugLevel, zap.String("headers", "Accept: */*")).AddTo(enc)
+
+	assert.Empty(t, enc.Fields)
+}
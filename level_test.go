@@ -0,0 +1,96 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestManager_PushLevel(t *testing.T) {
+	logger, err := New(WithLevel("info"))
+	require.NoError(t, err)
+
+	restoreDebug := logger.PushLevel(zapcore.DebugLevel)
+	assert.Equal(t, zapcore.DebugLevel, logger.level.Level())
+
+	restoreError := logger.PushLevel(zapcore.ErrorLevel)
+	assert.Equal(t, zapcore.ErrorLevel, logger.level.Level())
+
+	restoreError()
+	assert.Equal(t, zapcore.DebugLevel, logger.level.Level())
+
+	restoreError()
+	assert.Equal(t, zapcore.DebugLevel, logger.level.Level(), "restoring twice is a no-op")
+
+	restoreDebug()
+	assert.Equal(t, zapcore.InfoLevel, logger.level.Level())
+}
+
+func TestManager_PushLevel_OutOfOrder(t *testing.T) {
+	logger, err := New(WithLevel("warn"))
+	require.NoError(t, err)
+
+	restoreDebug := logger.PushLevel(zapcore.DebugLevel)
+	restoreInfo := logger.PushLevel(zapcore.InfoLevel)
+
+	restoreDebug()
+	assert.Equal(t, zapcore.InfoLevel, logger.level.Level(), "the innermost open scope keeps its level")
+
+	restoreInfo()
+	assert.Equal(t, zapcore.WarnLevel, logger.level.Level())
+
+	restoreInvalid := logger.PushLevel(zapcore.Level(100))
+	restoreInvalid()
+	assert.Equal(t, zapcore.WarnLevel, logger.level.Level())
+}
+
+func TestManager_Trace(t *testing.T) {
+	skipWithoutDebug(t)
+
+	tests := []struct {
+		name  string
+		level string
+		want  int
+	}{
+		{"suppressed at debug", "debug", 1},
+		{"emitted at trace", "trace", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opt := &option{}
+			WithLevel(tt.level)(opt)
+			level := zap.NewAtomicLevelAt(opt.level)
+			core, recorded := observer.New(level)
+			logger := &Manager{Zap: zap.New(core), level: level}
+
+			logger.Trace(context.Background(), "wire bytes", zap.Int("size", 512))
+			logger.Debug(context.Background(), "decoded frame")
+
+			require.Equal(t, tt.want, recorded.Len())
+			if tt.want == 2 {
+				assert.Equal(t, TraceLevel, recorded.All()[0].Level)
+			}
+		})
+	}
+
+	logger, err := New(WithLevel("trace"))
+	require.NoError(t, err)
+	assert.Equal(t, "trace", logger.Config().Level)
+}
+
+func TestLevelEncoder_Trace(t *testing.T) {
+	config := DefaultEncoderConfig
+	config.EncodeLevel = levelEncoder(&option{encoderConfig: DefaultEncoderConfig})
+
+	buf, err := zapcore.NewJSONEncoder(config).EncodeEntry(zapcore.Entry{Level: TraceLevel, Message: "trace"}, nil)
+	require.NoError(t, err)
+	defer buf.Free()
+
+	assert.Contains(t, buf.String(), `"L":"TRACE"`)
+}
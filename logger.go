@@ -5,7 +5,11 @@ package logger
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/lestrrat-go/file-rotatelogs"
@@ -22,6 +26,9 @@ const (
 	DPanicLevel
 	PanicLevel
 	FatalLevel
+
+	// TraceLevel is a custom level below DebugLevel for very verbose tracing
+	TraceLevel = DebugLevel - 1
 )
 
 // Constants for default configuration
@@ -31,6 +38,9 @@ const (
 	defaultCallerSkip      = 1
 	defaultStacktraceLevel = DPanicLevel
 	TraceIDKey             = "trace_id"
+	TraceIDField           = "TraceID" // Key of the field holding the TraceID
+	RequestIDKey           = "request_id"
+	RequestIDField         = "RequestID" // Key of the field holding the RequestID
 )
 
 type (
@@ -39,22 +49,108 @@ type (
 
 	// option holds the configuration for the logger
 	option struct {
-		driver          string                // Log driver: "stdout" or "file"
-		level           zapcore.Level         // Minimum log level
-		logPath         string                // Path for log files (only used when driver is "file")
-		encoderConfig   zapcore.EncoderConfig // Encoder configuration for log formatting
-		callerSkip      int                   // Number of stack frames to skip when logging caller info
-		maxAge          time.Duration         // Maximum age of log files before rotation
-		rotationTime    time.Duration         // Time between log file rotations
-		useColor        bool                  // Whether to use colored output (only for console encoder)
-		stacktraceLevel zapcore.Level         // Minimum log level for stacktrace
+		driver             string                               // Log driver: "stdout", "file", "eventlog" or "journald"
+		level              zapcore.Level                        // Minimum log level
+		logPath            string                               // Path for log files (only used when driver is "file")
+		encoderConfig      zapcore.EncoderConfig                // Encoder configuration for log formatting
+		callerSkip         int                                  // Number of stack frames to skip when logging caller info
+		maxAge             time.Duration                        // Maximum age of log files before rotation
+		rotationTime       time.Duration                        // Time between log file rotations
+		useColor           bool                                 // Whether to use colored output (only for console encoder)
+		encoding           string                               // Log encoding: "json", "console", "msgpack" or "gelf", derived from useColor when empty
+		timeFormat         string                               // Layout for timestamps, overrides the encoder config's EncodeTime when set
+		timezone           *time.Location                       // Location timestamps are converted to, nil keeps them unchanged
+		stacktraceLevel    zapcore.Level                        // Minimum log level for stacktrace
+		callerSampling     *callerSampling                      // Caller-keyed sampling, nil when disabled
+		callerMinLevel     *zapcore.Level                       // Minimum level of the entries written with their caller, nil for every entry
+		samplingHook       samplingHook                         // Called with every sampling decision, nil when unset
+		samplingExempt     []string                             // Message prefixes of the entries never sampled
+		levelSampling      *levelSampling                       // Sampling restricted to the less severe levels, nil when disabled
+		structuredStack    bool                                 // Whether stack traces are emitted as arrays of frames
+		structuredStackKey string                               // Key of the frame array, StacktraceKey when empty
+		splitByLevelDir    string                               // Base directory for per-level files (only used when driver is "file")
+		flatLevelFiles     bool                                 // Whether the per-level files are dated files in splitByLevelDir, linked from <level>.log
+		baseCtx            context.Context                      // Context consulted when the per-call context lacks a value
+		cleanupInterval    time.Duration                        // Time between scans for old log files, 0 disables (only used when driver is "file")
+		minRotationSize    int64                                // Minimum file size before a time-based rotation happens, 0 disables
+		rotationUTC        bool                                 // Whether files rotate at UTC boundaries and are named after the UTC date
+		schemaHeader       string                               // Header line written at the start of each new log file
+		encryptionKey      []byte                               // AES key the log files are encrypted with, nil disables
+		safeEncoding       bool                                 // Whether fields whose encoding panics are replaced by a placeholder
+		extraCores         []zapcore.Core                       // User-supplied cores teed with the configured output
+		sinks              []sink                               // Additional outputs with their own encoding
+		observed           *observedEntries                     // Entries retained by WithObserver, nil when disabled
+		events             map[string]struct{}                  // Event names accepted by Emit, nil accepts every name
+		writeTimeout       time.Duration                        // Maximum duration of a write, 0 disables
+		writeTimeouts      atomic.Uint64                        // Number of writes abandoned because of writeTimeout
+		stackDedupWindow   time.Duration                        // Window during which a repeated stack trace is suppressed, 0 to disable
+		writeLatency       *latencyHistogram                    // Duration of the writes, nil unless WithAsyncMetrics is set
+		fieldColors        bool                                 // Whether console fields are styled with ANSI colors
+		dedupeKeys         bool                                 // Whether fields sharing a key are collapsed
+		dedupeKeepLast     bool                                 // Whether the last occurrence of a duplicate key is kept
+		eventLogSource     string                               // Event source of the "eventlog" driver (Windows only)
+		panicHook          func(msg string, fields []zap.Field) // Called after a Panic entry is written, before panicking
+		noExitOnFatal      bool                                 // Whether Fatal entries return instead of calling os.Exit
+		startupSelfTest    bool                                 // Whether New writes a probe entry and fails if it cannot be written
+		goroutineID        bool                                 // Whether the logging goroutine's ID is added to every entry
+		defaultFieldsFuncs []func() []zap.Field                 // Functions computing fields added to every entry
+		plainFile          string                               // Single file written without rotation, selects the plain file mode of the "file" driver
+		reopenOnSignal     bool                                 // Whether the plain file is reopened on SIGHUP
+		reopenFile         *reopenFile                          // Plain file writer, nil unless plainFile is set
+		validateJSON       bool                                 // Whether every written line is checked to be valid JSON
+		validateJSONHook   func(line []byte)                    // Called with invalid lines, nil panics instead
+		levelStack         levelStack                           // Scopes opened by PushLevel
+		captures           captureSet                           // Captures opened by CaptureLogs
+		levelStrings       map[zapcore.Level]string             // Strings written for levels, overriding the encoder config's level encoding
+		structuredCaller   bool                                 // Whether the caller is written as separate file, line and function fields
+		onError            func(err error)                      // Called when a write to an output fails
+		reportingError     atomic.Bool                          // Set while onError runs
+		sequence           bool                                 // Whether every written entry carries a process-wide sequence number
+		buildInfo          bool                                 // Whether every entry carries the binary's version and VCS revision
+		instanceID         string                               // Random ID of the process added to every entry, empty when disabled
+		filters            []filterFunc                         // Predicates an entry must pass to be written
+		baggage            bool                                 // Whether the context's baggage is logged
+		baggageMaxKeys     int                                  // Maximum number of baggage members logged, <= 0 for no limit
+		contextDeadline    bool                                 // Whether the time left before the context's deadline is logged
+		goroutineContext   bool                                 // Whether the context set with SetContext is a fallback for the per-call context
+		root               *swappableCore                       // Core of the manager, replaced when the core is rebuilt
+		rebuild            sync.Mutex                           // Serializes rebuilding the core
+		replaced           atomic.Pointer[option]               // Options in effect after Reconfigure, nil while these are current
+		syncers            map[string]zapcore.WriteSyncer       // Outputs opened by the driver, keyed by stdout, pattern or path
+		cleaner            *cleaner                             // Scan for old log files, nil when disabled
+		callerThrottle     int                                  // Maximum number of entries per call site and second, 0 disables
+		flushOnError       bool                                 // Whether the outputs are synced after every entry at ErrorLevel or above
+		errorFlushSize     int                                  // Number of entries below Error held until an Error entry, 0 disables
+		errorFlushRing     *entryRing                           // Entries held by WithErrorTriggeredFlush, shared by rebuilt cores
+		fileFallback       time.Duration                        // Retry interval of the log file after falling back to stdout, 0 disables
+		fileFallbackHook   func(degraded bool, err error)       // Called when switching between the log file and stdout
+		messagePrefix      string                               // Prefix prepended to every message
+		fieldOrder         []string                             // Keys written first, in order
+		otlpEndpoint       string                               // Base URL of the OTLP/HTTP receiver, empty when disabled
+		otlp               *otlpExporter                        // Exporter of the OTLP records, started once
+		compactStack       bool                                 // Whether the stack traces are reduced to the application frames
+		maxFields          int                                  // Maximum number of fields per entry, 0 for no limit
+		numericLevelKey    string                               // Key of the numeric level field, empty when disabled
+		numericLevels      map[zapcore.Level]int                // Number written for each level
+		rateLimits         map[zapcore.Level]int                // Maximum number of entries per second, keyed by level
+		rateLimiters       map[zapcore.Level]*tokenBucket       // Token buckets of rateLimits, shared by rebuilt cores
+		routeKey           string                               // Key of the field selecting the routed file, routing is disabled when empty
+		routeDir           string                               // Directory of the routed files
+		routeFiles         *routeFiles                          // Open routed files, shared by rebuilt cores
+		journalConn        *net.UnixConn                        // Connection to the journal socket, shared by rebuilt cores
+		eventLogHandle     uintptr                              // Handle of the registered event source, shared by rebuilt cores
+		closers            []func() error                       // Releases the resources opened by New, run by Close
 	}
 
 	// Manager manages the logger instance and provides logging methods
 	Manager struct {
-		Zap        *zap.Logger     // Underlying Zap logger instance
-		level      zap.AtomicLevel // Atomic level for dynamic level changes
-		callerSkip CallerSkip      // Number of stack frames to skip when logging caller info
+		Zap        *zap.Logger         // Underlying Zap logger instance
+		level      zap.AtomicLevel     // Atomic level for dynamic level changes
+		callerSkip CallerSkip          // Number of stack frames to skip when logging caller info
+		skipCache  *skippedLoggerCache // Zap with callerSkip applied, nil to clone it on every call
+		baseCtx    context.Context     // Context consulted when the per-call context lacks a value
+		traceID    string              // TraceID bound with BoundTo, overrides the context's TraceID
+		opt        *option             // Resolved options the manager was created with
 	}
 )
 
@@ -76,7 +172,7 @@ var DefaultEncoderConfig = zapcore.EncoderConfig{
 // WithDriver sets the logger driver
 //
 // Parameters:
-//   - driver: The driver to use ("stdout" or "file")
+//   - driver: The driver to use ("stdout", "file", "eventlog" on Windows or "journald" on Linux)
 //
 // Returns:
 //   - Option: A function that sets the driver in the option struct
@@ -96,6 +192,8 @@ func WithDriver(driver string) Option {
 func WithLevel(level string) Option {
 	return func(o *option) {
 		switch level {
+		case "trace":
+			o.level = TraceLevel
 		case "debug":
 			o.level = DebugLevel
 		case "info":
@@ -194,6 +292,56 @@ func WithColor(useColor bool) Option {
 	}
 }
 
+// WithBaseContext sets a base context for values missing from the per-call context
+//
+// Useful for background jobs without a request context: set the job's trace ID once on the
+// base context and every entry carries it. Values in the per-call context take precedence.
+//
+// Parameters:
+//   - ctx: The base context
+//
+// Returns:
+//   - Option: A function that sets the base context in the option struct
+func WithBaseContext(ctx context.Context) Option {
+	return func(o *option) {
+		o.baseCtx = ctx
+	}
+}
+
+// WithExtraCore tees a user-supplied core alongside the configured output
+//
+// The extra core keeps its own level and encoder; the wrappers configured through other
+// options (sampling, structured stacks, ...) apply to it as well.
+//
+// Parameters:
+//   - core: The core to tee in
+//
+// Returns:
+//   - Option: A function that adds the core in the option struct
+func WithExtraCore(core zapcore.Core) Option {
+	return func(o *option) {
+		o.extraCores = append(o.extraCores, core)
+	}
+}
+
+// WithEncoding sets the log encoding
+//
+// When not set, the console encoder is used if colored output is enabled and JSON otherwise.
+// The "msgpack" encoding writes each entry as a length-prefixed MessagePack frame. The
+// "gelf" encoding writes each entry as a Graylog GELF 1.1 JSON message, with the fields as
+// _-prefixed additional fields and the level as its syslog severity.
+//
+// Parameters:
+//   - encoding: The encoding to use ("json", "console", "msgpack" or "gelf")
+//
+// Returns:
+//   - Option: A function that sets the encoding in the option struct
+func WithEncoding(encoding string) Option {
+	return func(o *option) {
+		o.encoding = encoding
+	}
+}
+
 // WithStacktraceLevel sets the minimum log level for stacktrace
 //
 // Parameters:
@@ -245,6 +393,101 @@ func WithStacktraceLevel(level string) Option {
 //	    // Handle error
 //	}
 func New(opts ...Option) (*Manager, error) {
+	opt, level, err := buildCore(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create Zap logger
+	zapOptions := []zap.Option{
+		zap.AddCaller(),
+		zap.ErrorOutput(zapcore.AddSync(os.Stderr)),
+		zap.AddStacktrace(opt.stacktraceLevel),
+	}
+	if opt.panicHook != nil {
+		zapOptions = append(zapOptions, zap.WithPanicHook(panicHook{core: opt.root, fn: opt.panicHook}))
+	}
+	if opt.noExitOnFatal {
+		zapOptions = append(zapOptions, zap.WithFatalHook(continueHook{core: opt.root}))
+	}
+	if opt.buildInfo {
+		zapOptions = append(zapOptions, zap.Fields(buildInfoFields()...))
+	}
+	if opt.instanceID != "" {
+		zapOptions = append(zapOptions, zap.Fields(zap.String(InstanceIDField, opt.instanceID)))
+	}
+	logger := zap.New(newCaptureCore(opt.root, &opt.captures), zapOptions...)
+
+	// Return new Manager instance
+	return &Manager{
+		Zap:        logger,
+		level:      level,
+		callerSkip: NewCallerSkip(opt.callerSkip),
+		skipCache:  &skippedLoggerCache{},
+		baseCtx:    opt.baseCtx,
+		opt:        opt,
+	}, nil
+}
+
+// BuildCore creates the core New would create, for composing a custom zap.Logger
+//
+// The core writes to the configured driver, sinks and extra cores, with the encoder,
+// rotation and every core feature enabled by the options. The options applied by New
+// through zap (caller, stack traces, panic and fatal hooks, build information) are left
+// to the caller, e.g. zap.New(core, zap.AddCaller()). The outputs opened for the core,
+// such as the rotated log files, stay open for the life of the process.
+//
+// Parameters:
+//   - opts: A variadic list of Option functions to configure the core
+//
+// Returns:
+//   - zapcore.Core: The configured core
+//   - zap.AtomicLevel: The level of the core, which can be changed at runtime
+//   - error: An error if the core cannot be created
+func BuildCore(opts ...Option) (zapcore.Core, zap.AtomicLevel, error) {
+	opt, level, err := buildCore(opts...)
+	if err != nil {
+		return nil, level, err
+	}
+
+	return opt.root, level, nil
+}
+
+// buildCore resolves the options and creates their level and core
+//
+// Parameters:
+//   - opts: A variadic list of Option functions to configure the core
+//
+// Returns:
+//   - *option: The resolved options, with the core set as their root
+//   - zap.AtomicLevel: The level of the core
+//   - error: An error if the core cannot be created
+func buildCore(opts ...Option) (*option, zap.AtomicLevel, error) {
+	opt := newOptions(opts...)
+
+	// Create atomic level for dynamic level changes
+	level := zap.NewAtomicLevelAt(opt.level)
+
+	// Create the core writing to the configured outputs
+	core, err := newSelfTestedCore(opt, level)
+	if err != nil {
+		return nil, level, err
+	}
+
+	// Allow the core to be rebuilt at runtime, e.g. by SetEncoding
+	opt.root = newSwappableCore(core)
+
+	return opt, level, nil
+}
+
+// newOptions creates the option struct from the defaults and the given options
+//
+// Parameters:
+//   - opts: A variadic list of Option functions to apply
+//
+// Returns:
+//   - *option: The options with the encoding and the encoders resolved
+func newOptions(opts ...Option) *option {
 	// Initialize default options
 	opt := &option{
 		driver:          defaultDriver,
@@ -261,46 +504,140 @@ func New(opts ...Option) (*Manager, error) {
 		f(opt)
 	}
 
-	// Create atomic level for dynamic level changes
-	level := zap.NewAtomicLevelAt(opt.level)
+	// Resolve the encoding from the color option when not set explicitly
+	if opt.encoding == "" {
+		opt.encoding = "json"
+		if opt.useColor {
+			opt.encoding = "console"
+		}
+	}
+
+	// Resolve the time encoder from the time format and timezone options
+	opt.encoderConfig.EncodeTime = timeEncoder(opt)
+
+	// Resolve the level encoder from the level strings option
+	opt.encoderConfig.EncodeLevel = levelEncoder(opt)
 
-	// Create encoder based on color option
-	var encoder zapcore.Encoder
-	if opt.useColor {
-		encoder = zapcore.NewConsoleEncoder(opt.encoderConfig)
-	} else {
-		encoder = zapcore.NewJSONEncoder(opt.encoderConfig)
+	return opt
+}
+
+// newCore creates the core writing to the configured driver and extra cores
+//
+// The outputs opened by the driver are cached in the options, so building the core
+// again, e.g. with another encoding, reuses them instead of opening them twice. The
+// startup self-test is not run, see newSelfTestedCore.
+//
+// Parameters:
+//   - opt: The option struct containing configuration
+//   - level: The zap.AtomicLevel for dynamic level changes
+//
+// Returns:
+//   - zapcore.Core: The core wrapped with every enabled feature
+//   - error: An error if the encoder or the driver cannot be created, or the encryption is invalid
+func newCore(opt *option, level zap.AtomicLevel) (zapcore.Core, error) {
+	core, err := newOutputCore(opt, level)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapCore(opt, core), nil
+}
+
+// newOutputCore creates the core writing to the configured outputs, before the feature wrappers
+//
+// Parameters:
+//   - opt: The option struct containing configuration
+//   - level: The zap.AtomicLevel for dynamic level changes
+//
+// Returns:
+//   - zapcore.Core: The core writing to the driver, the sinks and the extra cores
+//   - error: An error if the encoder or the driver cannot be created, or the encryption is invalid
+func newOutputCore(opt *option, level zap.AtomicLevel) (zapcore.Core, error) {
+	// Reject an encryption that would leave the entries in plaintext, whatever the driver
+	if err := validateEncryption(opt); err != nil {
+		return nil, err
+	}
+
+	// Create encoder based on encoding and color options
+	encoder, err := newEncoder(opt)
+	if err != nil {
+		return nil, err
 	}
 
 	var core zapcore.Core
-	var err error
 
 	// Create core based on driver
 	switch opt.driver {
 	case "stdout":
-		core = zapcore.NewCore(encoder, zapcore.AddSync(os.Stdout), level)
+		syncer, ok := opt.syncers["stdout"]
+		if !ok {
+			syncer = wrapSyncer(opt, zapcore.AddSync(os.Stdout))
+			opt.cacheSyncer("stdout", syncer)
+		}
+		core = newDirectCore(zapcore.NewCore(encoder, syncer, level))
 	case "file":
 		core, err = newFileCore(opt, encoder, level)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create file core: %w", err)
 		}
+	case "eventlog":
+		core, err = newEventLogCore(opt, encoder, level)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create eventlog core: %w", err)
+		}
+	case "journald":
+		core, err = newJournaldCore(opt, level)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create journald core: %w", err)
+		}
 	default:
 		return nil, fmt.Errorf("unknown driver: %s", opt.driver)
 	}
 
-	// Create Zap logger
-	logger := zap.New(core,
-		zap.AddCaller(),
-		zap.ErrorOutput(zapcore.AddSync(os.Stderr)),
-		zap.AddStacktrace(opt.stacktraceLevel),
-	)
+	// Tee in the sinks, the user-supplied cores and the OTLP export
+	sinks, err := newSinkCores(opt)
+	if err != nil {
+		return nil, err
+	}
+	others := append(sinks, opt.extraCores...)
+	if opt.routeKey != "" {
+		others = append(others, newRouteCore(opt, encoder, level))
+	}
+	if opt.otlpEndpoint != "" {
+		others = append(others, newOTLPCore(opt, level))
+	}
+	if len(others) > 0 {
+		core = newTeeCore(append([]zapcore.Core{core}, others...)...)
+	}
 
-	// Return new Manager instance
-	return &Manager{
-		Zap:        logger,
-		level:      level,
-		callerSkip: NewCallerSkip(opt.callerSkip),
-	}, nil
+	return core, nil
+}
+
+// newEncoder creates the encoder for the resolved encoding
+//
+// Parameters:
+//   - opt: The option struct containing configuration
+//
+// Returns:
+//   - zapcore.Encoder: The encoder to use
+//   - error: An error if the encoding is unknown
+func newEncoder(opt *option) (zapcore.Encoder, error) {
+	switch opt.encoding {
+	case "json":
+		return zapcore.NewJSONEncoder(opt.encoderConfig), nil
+	case "console":
+		encoder := zapcore.NewConsoleEncoder(opt.encoderConfig)
+		if fieldColorsEnabled(opt) {
+			encoder = newFieldColorEncoder(encoder)
+		}
+		return encoder, nil
+	case "msgpack":
+		return newMsgpackEncoder(opt.encoderConfig), nil
+	case "gelf":
+		return newGELFEncoder(opt.encoderConfig), nil
+	default:
+		return nil, fmt.Errorf("unknown encoding: %s", opt.encoding)
+	}
 }
 
 // newFileCore creates a new zapcore.Core for file-based logging
@@ -314,18 +651,217 @@ func New(opts ...Option) (*Manager, error) {
 //   - zapcore.Core: A new Core for file-based logging
 //   - error: An error if the file core creation fails
 func newFileCore(opt *option, encoder zapcore.Encoder, level zap.AtomicLevel) (zapcore.Core, error) {
-	// Create rotatelogs hook
-	hook, err := rotatelogs.New(
-		opt.logPath+"%Y-%m-%d.log",
+	if opt.plainFile != "" {
+		syncer, err := newPlainFileSyncer(opt)
+		if err != nil {
+			return nil, err
+		}
+
+		return newDirectCore(zapcore.NewCore(encoder, syncer, level)), nil
+	}
+
+	if opt.cleanupInterval > 0 && opt.cleaner == nil {
+		opt.cleaner = newCleaner(logFilePatterns(opt), opt.maxAge, opt.cleanupInterval)
+		opt.closers = append(opt.closers, opt.cleaner.Close)
+	}
+
+	if opt.splitByLevelDir != "" {
+		return newSplitFileCore(opt, encoder, level)
+	}
+
+	// Create rotated file syncer
+	syncer, err := newFileSyncer(opt, logFilePatterns(opt)[0], "")
+	if err != nil {
+		return nil, err
+	}
+
+	// Create and return new Core
+	return newDirectCore(zapcore.NewCore(encoder, syncer, level)), nil
+}
+
+// logFilePatterns returns the strftime patterns of the log files written by the file driver
+//
+// Parameters:
+//   - opt: The option struct containing configuration
+//
+// Returns:
+//   - []string: One pattern per log file
+func logFilePatterns(opt *option) []string {
+	if opt.splitByLevelDir == "" {
+		return []string{opt.logPath + "%Y-%m-%d.log"}
+	}
+
+	patterns := make([]string, 0, len(levelBuckets))
+	for _, bucket := range levelBuckets {
+		if opt.flatLevelFiles {
+			patterns = append(patterns, filepath.Join(opt.splitByLevelDir, bucket.name+".%Y-%m-%d.log"))
+			continue
+		}
+		patterns = append(patterns, filepath.Join(opt.splitByLevelDir, "%Y-%m-%d", bucket.name+".log"))
+	}
+
+	return patterns
+}
+
+// newFileSyncer creates the WriteSyncer writing to the files of the given pattern
+//
+// Parameters:
+//   - opt: The option struct containing configuration
+//   - pattern: The strftime pattern of the log file names
+//   - linkName: The path of a symlink to the current file, none when empty
+//
+// Returns:
+//   - zapcore.WriteSyncer: A syncer writing to rotated log files
+//   - error: An error if the pattern is invalid
+func newFileSyncer(opt *option, pattern, linkName string) (zapcore.WriteSyncer, error) {
+	if syncer, ok := opt.syncers[pattern]; ok {
+		return syncer, nil
+	}
+
+	clock := newRotationClock(opt, time.Now)
+	hookOptions := []rotatelogs.Option{
 		rotatelogs.WithMaxAge(opt.maxAge),
 		rotatelogs.WithRotationTime(opt.rotationTime),
-	)
+		rotatelogs.WithClock(clock),
+	}
+	if linkName != "" {
+		hookOptions = append(hookOptions, rotatelogs.WithLinkName(linkName))
+	}
+	hook, err := rotatelogs.New(pattern, hookOptions...)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create and return new Core
-	return zapcore.NewCore(encoder, zapcore.AddSync(hook), level), nil
+	opt.closers = append(opt.closers, hook.Close)
+
+	syncer, err := encryptFile(opt, zapcore.AddSync(hook))
+	if err != nil {
+		return nil, err
+	}
+	if opt.schemaHeader != "" {
+		syncer = newSchemaHeaderSyncer(hook, syncer, opt.schemaHeader)
+	}
+	if deferred, ok := clock.(*deferredClock); ok {
+		syncer = deferred.countWrites(syncer)
+	}
+
+	syncer = wrapSyncer(opt, withFileFallback(opt, syncer))
+	opt.cacheSyncer(pattern, syncer)
+
+	return syncer, nil
+}
+
+// wrapCore applies the optional core wrappers configured in the options
+//
+// Parameters:
+//   - opt: The option struct containing configuration
+//   - core: The core created for the configured driver
+//
+// Returns:
+//   - zapcore.Core: The core wrapped with every enabled feature
+func wrapCore(opt *option, core zapcore.Core) zapcore.Core {
+	if opt.safeEncoding {
+		core = newSafeEncodingCore(core, opt.onError, &opt.reportingError)
+	}
+
+	if opt.writeLatency != nil {
+		core = newWriteMetricsCore(core, opt.writeLatency)
+	}
+
+	if opt.messagePrefix != "" {
+		core = newMessagePrefixCore(core, opt.messagePrefix)
+	}
+
+	if opt.flushOnError {
+		core = newFlushOnErrorCore(core)
+	}
+
+	if opt.errorFlushSize > 0 {
+		if opt.errorFlushRing == nil {
+			opt.errorFlushRing = newEntryRing(opt.errorFlushSize)
+		}
+		core = newErrorTriggeredCore(core, opt.errorFlushRing)
+	}
+
+	if opt.maxFields > 0 {
+		core = newMaxFieldsCore(core, opt.maxFields)
+	}
+
+	if opt.dedupeKeys {
+		core = newDedupeCore(core, opt.dedupeKeepLast)
+	}
+
+	if len(opt.fieldOrder) > 0 {
+		core = newFieldOrderCore(core, opt.fieldOrder)
+	}
+
+	if opt.structuredStack {
+		key := opt.structuredStackKey
+		if key == "" {
+			key = opt.encoderConfig.StacktraceKey
+		}
+		core = newStructuredStackCore(core, key)
+	}
+
+	if opt.compactStack {
+		core = newCompactStackCore(core)
+	}
+
+	if opt.stackDedupWindow > 0 {
+		core = newStackDedupCore(core, opt.stackDedupWindow)
+	}
+
+	if opt.goroutineID {
+		core = newGoroutineCore(core)
+	}
+
+	if len(opt.defaultFieldsFuncs) > 0 {
+		core = newDefaultFieldsCore(core, opt.defaultFieldsFuncs)
+	}
+
+	if opt.structuredCaller {
+		core = newStructuredCallerCore(core)
+	}
+
+	if opt.callerMinLevel != nil {
+		core = newCallerLevelCore(core, *opt.callerMinLevel)
+	}
+
+	if opt.sequence {
+		core = newSequenceCore(core)
+	}
+
+	if opt.numericLevelKey != "" {
+		core = newNumericLevelCore(core, opt.numericLevelKey, opt.numericLevels)
+	}
+
+	if opt.callerSampling != nil {
+		core = newCallerSamplingCore(core, *opt.callerSampling, opt.samplingHook, opt.samplingExempt)
+	}
+
+	if opt.levelSampling != nil {
+		core = newLevelSamplingCore(core, *opt.levelSampling, opt.samplingHook, opt.samplingExempt)
+	}
+
+	if opt.callerThrottle > 0 {
+		core = newCallerThrottleCore(core, opt.callerThrottle)
+	}
+
+	if len(opt.rateLimits) > 0 {
+		if opt.rateLimiters == nil {
+			opt.rateLimiters = newTokenBuckets(opt.rateLimits)
+		}
+		core = newRateLimitCore(core, opt.rateLimiters)
+	}
+
+	if len(opt.filters) > 0 {
+		core = newFilterCore(core, opt.filters)
+	}
+
+	// Always applied, AtLevel fields are never written without it
+	core = newLevelFieldCore(core)
+
+	return core
 }
 
 // CallerSkipMode returns a new Manager with the given caller skip mode
@@ -337,11 +873,31 @@ func newFileCore(opt *option, encoder zapcore.Encoder, level zap.AtomicLevel) (z
 //   - *Manager: A new Manager with the specified caller skip mode
 func (m *Manager) CallerSkipMode(skip int) *Manager {
 	newManager := *m
+	newManager.skipCache = &skippedLoggerCache{}
 	newManager.callerSkip = NewCallerSkip(skip)
 
 	return &newManager
 }
 
+// BoundTo returns a new Manager whose entries always carry the given TraceID
+//
+// This is meant for background work spawned from a request: the returned Manager tags its
+// entries with the originating TraceID even when logging with a fresh context.
+// The bound TraceID takes precedence over the one found in the per-call context.
+//
+// Parameters:
+//   - traceID: The TraceID to bind
+//
+// Returns:
+//   - *Manager: A new Manager bound to the TraceID
+func (m *Manager) BoundTo(traceID string) *Manager {
+	newManager := *m
+	newManager.skipCache = &skippedLoggerCache{}
+	newManager.traceID = traceID
+
+	return &newManager
+}
+
 // getTraceIDFromContext extracts the TraceID from the context
 //
 // Parameters:
@@ -350,35 +906,138 @@ func (m *Manager) CallerSkipMode(skip int) *Manager {
 // Returns:
 //   - string: The extracted TraceID, or an empty string if not found
 func getTraceIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
 	if traceID, ok := ctx.Value(TraceIDKey).(string); ok {
 		return traceID
 	}
 	return ""
 }
 
-// getLoggerWithTraceID returns a logger with the TraceID field added if present in the context
+// getRequestIDFromContext extracts the RequestID from the context
 //
 // Parameters:
-//   - ctx: The context.Context to extract the TraceID from
+//   - ctx: The context.Context to extract the RequestID from
+//
+// Returns:
+//   - string: The extracted RequestID, or an empty string if not found
+func getRequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if requestID, ok := ctx.Value(RequestIDKey).(string); ok {
+		return requestID
+	}
+	return ""
+}
+
+// getLoggerWithTraceID returns a logger with the TraceID and RequestID fields added if present in the context
+//
+// A TraceID bound with BoundTo wins over the context. The goroutine's context (with
+// WithGoroutineContext) and then the base context are consulted when the per-call context
+// carries no TraceID or RequestID. The span ID and depth set by StartSpan are only read
+// from the per-call context. When the call-site fields already hold one of these fields, that
+// field wins and nothing is injected, so each key appears only once. A nil context is
+// treated as context.Background().
+//
+// Parameters:
+//   - ctx: The context.Context to extract the TraceID and RequestID from, may be nil
+//   - fields: The fields passed at the call site
 //
 // Returns:
-//   - *zap.Logger: A logger with the TraceID field added if present
-func (m *Manager) getLoggerWithTraceID(ctx context.Context) *zap.Logger {
-	logger := m.Zap.WithOptions(zap.AddCallerSkip(m.callerSkip.Load()))
-	traceID := getTraceIDFromContext(ctx)
+//   - *zap.Logger: A logger with the TraceID and RequestID fields added if present
+func (m *Manager) getLoggerWithTraceID(ctx context.Context, fields ...zap.Field) *zap.Logger {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	logger := m.skipCache.get(m.Zap, m.callerSkip.Load())
+
+	traceID := m.traceID
 	if traceID == "" {
+		traceID = getTraceIDFromContext(ctx)
+	}
+
+	requestID := getRequestIDFromContext(ctx)
+
+	if traceID == "" || requestID == "" {
+		for _, fallback := range m.fallbackContexts() {
+			if traceID == "" {
+				traceID = getTraceIDFromContext(fallback)
+			}
+			if requestID == "" {
+				requestID = getRequestIDFromContext(fallback)
+			}
+		}
+	}
+
+	spanID := getSpanIDFromContext(ctx)
+	spanDepth, hasSpanDepth := getSpanDepthFromContext(ctx)
+
+	for _, f := range fields {
+		switch f.Key {
+		case TraceIDField:
+			traceID = ""
+		case RequestIDField:
+			requestID = ""
+		case SpanIDField:
+			spanID = ""
+		case SpanDepthField:
+			hasSpanDepth = false
+		}
+	}
+
+	// Allocated lazily so entries without context fields do not allocate it
+	var contextFields []zap.Field
+	if traceID != "" {
+		contextFields = append(contextFields, zap.String(TraceIDField, traceID))
+	}
+	if requestID != "" {
+		contextFields = append(contextFields, zap.String(RequestIDField, requestID))
+	}
+	if spanID != "" {
+		contextFields = append(contextFields, zap.String(SpanIDField, spanID))
+	}
+	if hasSpanDepth {
+		contextFields = append(contextFields, zap.Int(SpanDepthField, spanDepth))
+	}
+	contextFields = append(contextFields, getRegisteredContextFields(ctx, fields)...)
+	if opt := m.options(); opt != nil {
+		if opt.baggage {
+			if baggage, ok := getBaggageFromContext(ctx, opt.baggageMaxKeys); ok {
+				contextFields = append(contextFields, baggage)
+			}
+		}
+		if opt.contextDeadline {
+			contextFields = append(contextFields, getDeadlineFields(ctx)...)
+		}
+	}
+	if len(contextFields) == 0 {
 		return logger
 	}
 
-	return logger.With(zap.String("TraceID", traceID))
+	return logger.With(contextFields...)
 }
 
 // SetLevel dynamically changes the log level
 //
+// Levels outside TraceLevel..FatalLevel are rejected: a warning is logged and the
+// current level is kept.
+//
 // Parameters:
 //   - level: The new zapcore.Level to set
-func (m *Manager) SetLevel(level zapcore.Level) {
+//
+// Returns:
+//   - bool: Whether the level was accepted
+func (m *Manager) SetLevel(level zapcore.Level) bool {
+	if level < TraceLevel || level > FatalLevel {
+		m.Zap.Warn("ignoring invalid log level", zap.Int8("level", int8(level)))
+		return false
+	}
+
 	m.level.SetLevel(level)
+	return true
 }
 
 // SetCallerSkip sets the number of callers to skip when logging
@@ -396,7 +1055,7 @@ func (m *Manager) SetCallerSkip(skip int) {
 //   - msg: The message to log
 //   - fields: Optional fields to add to the log entry
 func (m *Manager) Info(ctx context.Context, msg string, fields ...zap.Field) {
-	logger := m.getLoggerWithTraceID(ctx)
+	logger := m.getLoggerWithTraceID(ctx, fields...)
 	logger.Info(msg, fields...)
 }
 
@@ -407,21 +1066,10 @@ func (m *Manager) Info(ctx context.Context, msg string, fields ...zap.Field) {
 //   - msg: The message to log
 //   - fields: Optional fields to add to the log entry
 func (m *Manager) Error(ctx context.Context, msg string, fields ...zap.Field) {
-	logger := m.getLoggerWithTraceID(ctx)
+	logger := m.getLoggerWithTraceID(ctx, fields...)
 	logger.Error(msg, fields...)
 }
 
-// Debug logs a message at DebugLevel
-//
-// Parameters:
-//   - ctx: The context.Context for this log entry
-//   - msg: The message to log
-//   - fields: Optional fields to add to the log entry
-func (m *Manager) Debug(ctx context.Context, msg string, fields ...zap.Field) {
-	logger := m.getLoggerWithTraceID(ctx)
-	logger.Debug(msg, fields...)
-}
-
 // Warn logs a message at WarnLevel
 //
 // Parameters:
@@ -429,18 +1077,20 @@ func (m *Manager) Debug(ctx context.Context, msg string, fields ...zap.Field) {
 //   - msg: The message to log
 //   - fields: Optional fields to add to the log entry
 func (m *Manager) Warn(ctx context.Context, msg string, fields ...zap.Field) {
-	logger := m.getLoggerWithTraceID(ctx)
+	logger := m.getLoggerWithTraceID(ctx, fields...)
 	logger.Warn(msg, fields...)
 }
 
 // Fatal logs a message at FatalLevel with a stack trace, then calls os.Exit(1)
 //
+// With WithExitOnFatal(false), Fatal returns after logging instead of exiting.
+//
 // Parameters:
 //   - ctx: The context.Context for this log entry
 //   - msg: The message to log
 //   - fields: Optional fields to add to the log entry
 func (m *Manager) Fatal(ctx context.Context, msg string, fields ...zap.Field) {
-	logger := m.getLoggerWithTraceID(ctx)
+	logger := m.getLoggerWithTraceID(ctx, fields...)
 	logger.Fatal(msg, fields...)
 }
 
@@ -451,10 +1101,114 @@ func (m *Manager) Fatal(ctx context.Context, msg string, fields ...zap.Field) {
 //   - msg: The message to log
 //   - fields: Optional fields to add to the log entry
 func (m *Manager) Panic(ctx context.Context, msg string, fields ...zap.Field) {
-	logger := m.getLoggerWithTraceID(ctx)
+	logger := m.getLoggerWithTraceID(ctx, fields...)
 	logger.Panic(msg, fields...)
 }
 
+// Log logs a message at the given level
+//
+// The level may be chosen at runtime. DPanic, Panic and Fatal entries keep their usual
+// semantics: Panic panics after logging, Fatal calls os.Exit(1).
+//
+// Parameters:
+//   - ctx: The context.Context for this log entry
+//   - level: The level to log at
+//   - msg: The message to log
+//   - fields: Optional fields to add to the log entry
+func (m *Manager) Log(ctx context.Context, level zapcore.Level, msg string, fields ...zap.Field) {
+	logger := m.getLoggerWithTraceID(ctx, fields...)
+	logger.Log(level, msg, fields...)
+}
+
+// LogError logs an error at the level chosen by the classifier
+//
+// The error message is used as the log message and the error is attached as the "error" field.
+// Expected errors can be classified as Debug or Info, unexpected ones as Error.
+//
+// Parameters:
+//   - ctx: The context.Context for this log entry
+//   - err: The error to log, nothing is logged when nil
+//   - classifier: Returns the level for the error, ErrorLevel is used when nil
+func (m *Manager) LogError(ctx context.Context, err error, classifier func(error) zapcore.Level) {
+	if err == nil {
+		return
+	}
+
+	level := ErrorLevel
+	if classifier != nil {
+		level = classifier(err)
+	}
+
+	logger := m.getLoggerWithTraceID(ctx)
+	logger.Log(level, err.Error(), zap.Error(err))
+}
+
+// LogIf logs a message at the given level only if cond is true
+//
+// Parameters:
+//   - cond: Whether the message is logged
+//   - ctx: The context.Context for this log entry
+//   - level: The level to log at
+//   - msg: The message to log
+//   - fields: Optional fields to add to the log entry
+func (m *Manager) LogIf(cond bool, ctx context.Context, level zapcore.Level, msg string, fields ...zap.Field) {
+	if !cond {
+		return
+	}
+
+	logger := m.getLoggerWithTraceID(ctx, fields...)
+	logger.Log(level, msg, fields...)
+}
+
+// LogIfError logs a message at ErrorLevel only if err is not nil
+//
+// The error is attached as the "error" field.
+//
+// Parameters:
+//   - ctx: The context.Context for this log entry
+//   - err: The error to log, nothing is logged when nil
+//   - msg: The message to log
+//   - fields: Optional fields to add to the log entry
+func (m *Manager) LogIfError(ctx context.Context, err error, msg string, fields ...zap.Field) {
+	if err == nil {
+		return
+	}
+
+	fields = append(fields[:len(fields):len(fields)], zap.Error(err))
+	logger := m.getLoggerWithTraceID(ctx, fields...)
+	logger.Error(msg, fields...)
+}
+
+// Core returns the core of the underlying Zap logger
+//
+// Useful to compose the configured output with other cores, e.g. through zapcore.NewTee.
+//
+// Returns:
+//   - zapcore.Core: The configured core
+func (m *Manager) Core() zapcore.Core {
+	return m.Zap.Core()
+}
+
+// TeeTo returns a new Manager writing to both this manager's and the other manager's outputs
+//
+// Each output keeps its own level, so an entry is written wherever it is enabled. The
+// returned manager keeps this manager's options, level and caller skip.
+//
+// Parameters:
+//   - other: The manager whose output is added
+//
+// Returns:
+//   - *Manager: A new Manager writing to both outputs
+func (m *Manager) TeeTo(other *Manager) *Manager {
+	newManager := *m
+	newManager.skipCache = &skippedLoggerCache{}
+	newManager.Zap = m.Zap.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, other.Zap.Core())
+	}))
+
+	return &newManager
+}
+
 // Sync flushes any buffered log entries
 //
 // Returns:
@@ -463,6 +1217,21 @@ func (m *Manager) Sync() error {
 	return m.Zap.Sync()
 }
 
+// Close releases the resources opened by New, such as log files and background workers
+//
+// The manager must not be used after Close. Call Sync first to flush buffered entries.
+//
+// Returns:
+//   - error: The errors returned while releasing the resources
+func (m *Manager) Close() error {
+	opt := m.options()
+	if opt == nil {
+		return nil
+	}
+
+	return opt.close()
+}
+
 // Named adds a sub-scope to the logger's name
 //
 // Parameters:
@@ -485,6 +1254,6 @@ func (m *Manager) Named(ctx context.Context, name string) *zap.Logger {
 // Returns:
 //   - *zap.Logger: A new logger with the given fields added
 func (m *Manager) With(ctx context.Context, fields ...zap.Field) *zap.Logger {
-	logger := m.getLoggerWithTraceID(ctx)
+	logger := m.getLoggerWithTraceID(ctx, fields...)
 	return logger.With(fields...)
 }
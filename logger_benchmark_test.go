@@ -12,6 +12,20 @@ func BenchmarkManager_Info(b *testing.B) {
 	logger, _ := New()
 	ctx := context.Background()
 
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Info(ctx, "benchmark info message")
+	}
+}
+
+// BenchmarkManager_InfoCallerMinLevel writes Info entries without their caller, to compare
+// with BenchmarkManager_Info
+func BenchmarkManager_InfoCallerMinLevel(b *testing.B) {
+	logger, _ := New(WithCallerMinLevel(zapcore.WarnLevel))
+	ctx := context.Background()
+
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		logger.Info(ctx, "benchmark info message")
@@ -22,6 +36,21 @@ func BenchmarkManager_InfoWithFields(b *testing.B) {
 	logger, _ := New()
 	ctx := context.Background()
 
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Info(ctx, "benchmark info message", zap.Int("count", i), zap.String("benchmark", "true"))
+	}
+}
+
+// BenchmarkManager_InfoWithFieldsUncached clones the logger on every call, as before the
+// caller skip cache, to compare its allocations with BenchmarkManager_InfoWithFields
+func BenchmarkManager_InfoWithFieldsUncached(b *testing.B) {
+	logger, _ := New()
+	logger.skipCache = nil
+	ctx := context.Background()
+
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		logger.Info(ctx, "benchmark info message", zap.Int("count", i), zap.String("benchmark", "true"))
@@ -32,6 +61,7 @@ func BenchmarkManager_Error(b *testing.B) {
 	logger, _ := New()
 	ctx := context.Background()
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		logger.Error(ctx, "benchmark error message")
@@ -42,6 +72,7 @@ func BenchmarkManager_WithTraceID(b *testing.B) {
 	logger, _ := New()
 	ctx := context.WithValue(context.Background(), TraceIDKey, "benchmark-trace-id")
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		logger.Info(ctx, "benchmark message with trace id")
@@ -51,6 +82,7 @@ func BenchmarkManager_WithTraceID(b *testing.B) {
 func BenchmarkManager_SetLevel(b *testing.B) {
 	logger, _ := New()
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		logger.SetLevel(zapcore.InfoLevel)
@@ -2,10 +2,14 @@ package logger
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
+	"strings"
 	"testing"
 )
 
@@ -104,11 +108,23 @@ func TestManager_SetLevel(t *testing.T) {
 	assert.Equal(t, zapcore.ErrorLevel, logger.level.Level())
 }
 
+func TestManager_SetLevel_Invalid(t *testing.T) {
+	logger, err := New(WithLevel("info"))
+	assert.NoError(t, err)
+
+	assert.False(t, logger.SetLevel(zapcore.Level(100)))
+	assert.False(t, logger.SetLevel(zapcore.Level(-100)))
+	assert.Equal(t, zapcore.InfoLevel, logger.level.Level())
+
+	assert.True(t, logger.SetLevel(zapcore.WarnLevel))
+	assert.Equal(t, zapcore.WarnLevel, logger.level.Level())
+}
+
 func TestManager_Named(t *testing.T) {
 	logger, err := New()
 	assert.NoError(t, err)
 
-	named := logger.Named("test")
+	named := logger.Named(context.Background(), "test")
 	assert.NotNil(t, named)
 }
 
@@ -116,6 +132,215 @@ func TestManager_With(t *testing.T) {
 	logger, err := New()
 	assert.NoError(t, err)
 
-	with := logger.With(zap.String("key", "value"))
+	with := logger.With(context.Background(), zap.String("key", "value"))
 	assert.NotNil(t, with)
 }
+
+func TestManager_Log(t *testing.T) {
+	core, recorded := observer.New(zapcore.DebugLevel)
+	logger := &Manager{
+		Zap:        zap.New(core, zap.AddCaller()),
+		callerSkip: NewCallerSkip(defaultCallerSkip),
+	}
+
+	ctx := context.Background()
+	for _, level := range []zapcore.Level{DebugLevel, InfoLevel, WarnLevel, ErrorLevel} {
+		logger.Log(ctx, level, "dynamic level")
+	}
+
+	entries := recorded.All()
+	assert.Equal(t, 4, len(entries))
+	for i, level := range []zapcore.Level{DebugLevel, InfoLevel, WarnLevel, ErrorLevel} {
+		assert.Equal(t, level, entries[i].Level)
+		assert.True(t, strings.HasSuffix(entries[i].Caller.File, "logger_test.go"))
+	}
+
+	assert.Panics(t, func() {
+		logger.Log(ctx, PanicLevel, "dynamic panic")
+	})
+	assert.Equal(t, PanicLevel, recorded.All()[recorded.Len()-1].Level)
+}
+
+func TestManager_LogError(t *testing.T) {
+	core, recorded := observer.New(zapcore.DebugLevel)
+	logger := &Manager{
+		Zap: zap.New(core),
+	}
+
+	errCacheMiss := errors.New("cache miss")
+	classifier := func(err error) zapcore.Level {
+		if errors.Is(err, errCacheMiss) {
+			return DebugLevel
+		}
+		return ErrorLevel
+	}
+
+	ctx := context.Background()
+	logger.LogError(ctx, fmt.Errorf("get user: %w", errCacheMiss), classifier)
+	logger.LogError(ctx, errors.New("connection refused"), classifier)
+	logger.LogError(ctx, nil, classifier)
+
+	entries := recorded.All()
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, DebugLevel, entries[0].Level)
+	assert.Equal(t, "get user: cache miss", entries[0].Message)
+	assert.Equal(t, "get user: cache miss", entries[0].ContextMap()["error"])
+	assert.Equal(t, ErrorLevel, entries[1].Level)
+	assert.Equal(t, "connection refused", entries[1].ContextMap()["error"])
+}
+
+func TestManager_LogIf(t *testing.T) {
+	core, recorded := observer.New(zapcore.DebugLevel)
+	logger := &Manager{
+		Zap:        zap.New(core, zap.AddCaller()),
+		callerSkip: NewCallerSkip(defaultCallerSkip),
+	}
+
+	ctx := context.Background()
+	logger.LogIf(false, ctx, WarnLevel, "skipped")
+	logger.LogIf(true, ctx, WarnLevel, "logged", zap.Int("attempt", 3))
+
+	require.Equal(t, 1, recorded.Len())
+	entry := recorded.All()[0]
+	assert.Equal(t, WarnLevel, entry.Level)
+	assert.Equal(t, "logged", entry.Message)
+	assert.Equal(t, map[string]interface{}{"attempt": int64(3)}, entry.ContextMap())
+	assert.True(t, strings.HasSuffix(entry.Caller.File, "logger_test.go"))
+}
+
+func TestManager_LogIfError(t *testing.T) {
+	core, recorded := observer.New(zapcore.DebugLevel)
+	logger := &Manager{
+		Zap:        zap.New(core, zap.AddCaller()),
+		callerSkip: NewCallerSkip(defaultCallerSkip),
+	}
+
+	ctx := context.Background()
+	logger.LogIfError(ctx, nil, "save failed")
+	logger.LogIfError(ctx, errors.New("disk full"), "save failed", zap.String("file", "a.txt"))
+
+	require.Equal(t, 1, recorded.Len())
+	entry := recorded.All()[0]
+	assert.Equal(t, ErrorLevel, entry.Level)
+	assert.Equal(t, "save failed", entry.Message)
+	assert.Equal(t, map[string]interface{}{"file": "a.txt", "error": "disk full"}, entry.ContextMap())
+	assert.True(t, strings.HasSuffix(entry.Caller.File, "logger_test.go"))
+}
+
+func TestManager_WithBaseContext(t *testing.T) {
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger := &Manager{
+		Zap:     zap.New(core),
+		baseCtx: context.WithValue(context.Background(), TraceIDKey, "job-42"),
+	}
+
+	logger.Info(context.Background(), "from base")
+	logger.Info(context.WithValue(context.Background(), TraceIDKey, "request-7"), "from call")
+
+	entries := recorded.All()
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, "job-42", entries[0].ContextMap()["TraceID"])
+	assert.Equal(t, "request-7", entries[1].ContextMap()["TraceID"])
+}
+
+func TestManager_BoundTo(t *testing.T) {
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger := &Manager{Zap: zap.New(core)}
+	bound := logger.BoundTo("request-7")
+
+	bound.Info(context.Background(), "background task")
+	bound.Info(context.WithValue(context.Background(), TraceIDKey, "other"), "bound wins")
+	logger.Info(context.Background(), "parent unaffected")
+
+	entries := recorded.All()
+	assert.Equal(t, 3, len(entries))
+	assert.Equal(t, "request-7", entries[0].ContextMap()["TraceID"])
+	assert.Equal(t, "request-7", entries[1].ContextMap()["TraceID"])
+	assert.NotContains(t, entries[2].ContextMap(), "TraceID")
+}
+
+func TestManager_ExplicitTraceIDField(t *testing.T) {
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger := &Manager{Zap: zap.New(core)}
+	ctx := context.WithValue(context.Background(), TraceIDKey, "from-context")
+
+	logger.Info(ctx, "explicit", zap.String(TraceIDField, "from-field"))
+	logger.With(ctx, zap.String(TraceIDField, "from-with")).Info("derived")
+	logger.Info(ctx, "injected")
+
+	entries := recorded.All()
+	require.Equal(t, 3, len(entries))
+	for i, want := range []string{"from-field", "from-with", "from-context"} {
+		var traceIDs []string
+		for _, f := range entries[i].Context {
+			if f.Key == TraceIDField {
+				traceIDs = append(traceIDs, f.String)
+			}
+		}
+		assert.Equal(t, []string{want}, traceIDs)
+	}
+}
+
+func TestManager_WithExtraCore(t *testing.T) {
+	skipWithoutDebug(t)
+
+	primary, primaryLogs := observer.New(zapcore.InfoLevel)
+	extra, extraLogs := observer.New(zapcore.DebugLevel)
+
+	logger, err := New(WithLevel("debug"), WithExtraCore(extra))
+	assert.NoError(t, err)
+
+	// Compose the configured core with another one
+	composed := &Manager{Zap: zap.New(zapcore.NewTee(logger.Core(), primary))}
+
+	composed.Info(context.Background(), "to every core")
+	composed.Debug(context.Background(), "below primary level")
+
+	assert.Equal(t, 1, primaryLogs.Len())
+	assert.Equal(t, 2, extraLogs.Len())
+	assert.Equal(t, "to every core", extraLogs.All()[0].Message)
+}
+
+func TestManager_TeeTo(t *testing.T) {
+	appCore, appLogs := observer.New(zapcore.InfoLevel)
+	auditCore, auditLogs := observer.New(zapcore.WarnLevel)
+	app := &Manager{Zap: zap.New(appCore)}
+	audit := &Manager{Zap: zap.New(auditCore)}
+
+	both := app.TeeTo(audit)
+	both.Warn(context.Background(), "permission changed")
+	both.Info(context.Background(), "only app")
+
+	assert.Equal(t, 2, appLogs.Len())
+	assert.Equal(t, 1, auditLogs.Len())
+	assert.Equal(t, "permission changed", auditLogs.All()[0].Message)
+
+	// The original managers are unchanged
+	app.Warn(context.Background(), "app only")
+	assert.Equal(t, 1, auditLogs.Len())
+}
+
+// skipWithoutDebug skips tests relying on Debug or Trace output in builds with the nodebug tag
+func skipWithoutDebug(t *testing.T) {
+	t.Helper()
+	if !debugCompiled {
+		t.Skip("Debug and Trace are compiled out by the nodebug tag")
+	}
+}
+
+func TestManager_NilContext(t *testing.T) {
+	core, recorded := observer.New(zapcore.InfoLevel)
+	opt := &option{baggage: true, contextDeadline: true, baseCtx: context.WithValue(context.Background(), TraceIDKey, "base")}
+	logger := &Manager{Zap: zap.New(core), opt: opt, baseCtx: opt.baseCtx}
+
+	assert.NotPanics(t, func() {
+		logger.Info(nil, "nil context", zap.Int("n", 1))
+		_, end := logger.StartSpan(nil, "job")
+		end()
+	})
+
+	entries := recorded.All()
+	require.Len(t, entries, 3)
+	assert.Equal(t, "nil context", entries[0].Message)
+	assert.Equal(t, "base", entries[0].ContextMap()[TraceIDField])
+}
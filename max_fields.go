@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// maxFieldsCore is a zapcore.Core dropping the fields of an entry beyond a maximum
+//
+// Context fields added through With are kept on the core instead of being encoded upfront,
+// so they count towards the maximum together with the fields passed at the call site.
+type maxFieldsCore struct {
+	zapcore.Core
+	levelChecked
+	max     int
+	context []zapcore.Field // Fields added through With
+}
+
+// WithMaxFields caps the number of fields written per entry
+//
+// Fields added through With count first, then the fields passed at the call site; the
+// fields beyond n are dropped and a "fields_truncated" field with the number of dropped
+// fields is written first, outside any namespace. This protects ingestion from a bug
+// attaching hundreds of fields to one entry. Context fields are encoded with every entry
+// instead of once, which costs some throughput.
+//
+// Parameters:
+//   - n: The maximum number of fields per entry
+//
+// Returns:
+//   - Option: A function that sets the maximum number of fields in the option struct
+func WithMaxFields(n int) Option {
+	return func(o *option) {
+		o.maxFields = n
+	}
+}
+
+// newMaxFieldsCore wraps a core to cap the number of fields per entry
+//
+// Parameters:
+//   - core: The core to wrap
+//   - max: The maximum number of fields per entry
+//
+// Returns:
+//   - zapcore.Core: A core writing at most max fields per entry
+func newMaxFieldsCore(core zapcore.Core, max int) zapcore.Core {
+	return &maxFieldsCore{Core: core, max: max}
+}
+
+// With keeps the fields on the core so they count towards the maximum at write time
+func (c *maxFieldsCore) With(fields []zapcore.Field) zapcore.Core {
+	context := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	context = append(context, c.context...)
+	context = append(context, fields...)
+
+	return &maxFieldsCore{Core: c.Core, max: c.max, context: context}
+}
+
+// Check adds the core so Write can count the context and call-site fields
+func (c *maxFieldsCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write writes the entry with at most max fields
+func (c *maxFieldsCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	total := len(c.context) + len(fields)
+	if total <= c.max {
+		all := make([]zapcore.Field, 0, total)
+		all = append(all, c.context...)
+		all = append(all, fields...)
+		return writeEntry(c.Core, ent, all)
+	}
+
+	// Prepend the marker so it stays outside any namespace opened by the kept fields
+	all := make([]zapcore.Field, 0, c.max+1)
+	all = append(all, zap.Int("fields_truncated", total-c.max))
+	all = append(all, c.context[:min(len(c.context), c.max)]...)
+	if kept := c.max - len(c.context); kept > 0 {
+		all = append(all, fields[:kept]...)
+	}
+
+	return writeEntry(c.Core, ent, all)
+}
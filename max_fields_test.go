@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithMaxFields(t *testing.T) {
+	opt := &option{}
+	WithMaxFields(3)(opt)
+
+	obs, recorded := observer.New(zapcore.InfoLevel)
+	logger := zap.New(wrapCore(opt, obs)).With(zap.String("service", "billing"))
+
+	fields := make([]zap.Field, 0, 100)
+	for i := 0; i < 100; i++ {
+		fields = append(fields, zap.Int("f"+strconv.Itoa(i), i))
+	}
+	logger.Info("pathological", fields...)
+	logger.Info("small", zap.String("order", "o-1"))
+
+	require.Equal(t, 2, recorded.Len())
+	entries := recorded.All()
+	assert.Equal(t, map[string]interface{}{
+		"fields_truncated": int64(98),
+		"service":          "billing",
+		"f0":               int64(0),
+		"f1":               int64(1),
+	}, entries[0].ContextMap())
+	assert.Equal(t, "fields_truncated", entries[0].Context[0].Key)
+	assert.Equal(t, map[string]interface{}{"service": "billing", "order": "o-1"}, entries[1].ContextMap())
+}
+
+func TestWithMaxFields_ContextOverLimit(t *testing.T) {
+	obs, recorded := observer.New(zapcore.InfoLevel)
+	logger := zap.New(newMaxFieldsCore(obs, 1)).With(zap.String("a", "1"), zap.String("b", "2"))
+	logger.Info("context only", zap.String("c", "3"))
+
+	require.Equal(t, 1, recorded.Len())
+	assert.Equal(t, map[string]interface{}{"fields_truncated": int64(2), "a": "1"}, recorded.All()[0].ContextMap())
+}
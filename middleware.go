@@ -0,0 +1,241 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type (
+	// MiddlewareOption is a function that configures the HTTP middleware
+	MiddlewareOption func(*middlewareOption)
+
+	// middlewareOption holds the configuration for the HTTP middleware
+	middlewareOption struct {
+		logBodies       bool   // Whether textual request and response bodies are logged at Debug
+		maxBodySize     int    // Maximum number of body bytes logged per body
+		requestIDHeader string // Header carrying the RequestID, not read when empty
+		traceIDHeader   string // Header carrying the TraceID, not read when empty
+	}
+
+	// responseRecorder captures the status and the beginning of the response body
+	responseRecorder struct {
+		http.ResponseWriter
+		status    int
+		body      *bytes.Buffer // Captured body, nil when bodies are not logged
+		limit     int
+		truncated bool
+	}
+
+	// bodyReader restores a partially read request body for the handler
+	bodyReader struct {
+		io.Reader
+		io.Closer
+	}
+)
+
+// WithBodyLogging logs textual request and response bodies at Debug level
+//
+// Only text and JSON-like content types are logged, binary bodies are skipped.
+// Bodies longer than maxSize are truncated; the handler still sees the full request body.
+// A negative maxSize is treated as 0, logging only whether each body was non-empty.
+//
+// Parameters:
+//   - maxSize: The maximum number of bytes logged per body
+//
+// Returns:
+//   - MiddlewareOption: A function that enables body logging in the middleware options
+func WithBodyLogging(maxSize int) MiddlewareOption {
+	return func(o *middlewareOption) {
+		o.logBodies = true
+		o.maxBodySize = max(maxSize, 0)
+	}
+}
+
+// WithRequestIDHeader reads the RequestID of each request from the given header
+//
+// The RequestID identifies a single hop, as opposed to the TraceID which spans the whole
+// trace. When the header is present, its value is stored in the request context under
+// RequestIDKey, so it is logged by the middleware and by every log call using that context.
+//
+// Parameters:
+//   - header: The header name, e.g. "X-Request-ID"
+//
+// Returns:
+//   - MiddlewareOption: A function that sets the RequestID header in the middleware options
+func WithRequestIDHeader(header string) MiddlewareOption {
+	return func(o *middlewareOption) {
+		o.requestIDHeader = header
+	}
+}
+
+// WithTraceIDHeader reads the TraceID of each request from the given header
+//
+// When the header is present, its value is stored in the request context under TraceIDKey,
+// so it is logged by the middleware and by every log call using that context.
+//
+// Parameters:
+//   - header: The header name, e.g. "X-Trace-ID"
+//
+// Returns:
+//   - MiddlewareOption: A function that sets the TraceID header in the middleware options
+func WithTraceIDHeader(header string) MiddlewareOption {
+	return func(o *middlewareOption) {
+		o.traceIDHeader = header
+	}
+}
+
+// Middleware returns an HTTP middleware that logs one line per request
+//
+// Parameters:
+//   - opts: A variadic list of MiddlewareOption functions to configure the middleware
+//
+// Returns:
+//   - func(http.Handler) http.Handler: The middleware
+func (m *Manager) Middleware(opts ...MiddlewareOption) func(http.Handler) http.Handler {
+	opt := &middlewareOption{}
+	for _, f := range opts {
+		f(opt)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ctx := r.Context()
+			if id := headerValue(r, opt.traceIDHeader); id != "" {
+				ctx = context.WithValue(ctx, TraceIDKey, id)
+			}
+			if id := headerValue(r, opt.requestIDHeader); id != "" {
+				ctx = context.WithValue(ctx, RequestIDKey, id)
+			}
+			r = r.WithContext(ctx)
+			logBodies := opt.logBodies && m.Zap.Core().Enabled(DebugLevel)
+
+			var requestBody []byte
+			var requestTruncated bool
+			if logBodies && isTextContent(r.Header.Get("Content-Type")) && r.Body != nil {
+				requestBody, requestTruncated = peekBody(r, opt.maxBodySize)
+			}
+
+			rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK, limit: opt.maxBodySize}
+			if logBodies {
+				rec.body = &bytes.Buffer{}
+			}
+
+			next.ServeHTTP(rec, r)
+
+			m.Info(ctx, "http request",
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+				zap.Int("status", rec.status),
+				Latency("duration_ms", time.Since(start)),
+			)
+
+			if !logBodies {
+				return
+			}
+
+			fields := make([]zap.Field, 0, 4)
+			if requestBody != nil {
+				fields = append(fields, zap.ByteString("request_body", requestBody), zap.Bool("request_body_truncated", requestTruncated))
+			}
+			if isTextContent(rec.Header().Get("Content-Type")) {
+				fields = append(fields, zap.ByteString("response_body", rec.body.Bytes()), zap.Bool("response_body_truncated", rec.truncated))
+			}
+			if len(fields) > 0 {
+				m.Debug(ctx, "http body", fields...)
+			}
+		})
+	}
+}
+
+// headerValue returns the value of the header, or an empty string if the header name is empty
+func headerValue(r *http.Request, header string) string {
+	if header == "" {
+		return ""
+	}
+
+	return r.Header.Get(header)
+}
+
+// peekBody reads up to limit bytes of the request body and restores it for the handler
+func peekBody(r *http.Request, limit int) ([]byte, bool) {
+	buf, _ := io.ReadAll(io.LimitReader(r.Body, int64(limit)+1))
+	r.Body = bodyReader{Reader: io.MultiReader(bytes.NewReader(buf), r.Body), Closer: r.Body}
+
+	if len(buf) > limit {
+		return buf[:limit], true
+	}
+
+	return buf, false
+}
+
+// isTextContent reports whether a content type holds text that is safe to log
+func isTextContent(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "text/"):
+		return true
+	case mediaType == "application/json", strings.HasSuffix(mediaType, "+json"):
+		return true
+	case mediaType == "application/xml", strings.HasSuffix(mediaType, "+xml"):
+		return true
+	case mediaType == "application/x-www-form-urlencoded":
+		return true
+	}
+
+	return false
+}
+
+// WriteHeader records the status code
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write captures the beginning of the body before writing it
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.body != nil {
+		remaining := r.limit - r.body.Len()
+		switch {
+		case remaining >= len(b):
+			r.body.Write(b)
+		case remaining > 0:
+			r.body.Write(b[:remaining])
+			r.truncated = true
+		default:
+			r.truncated = r.truncated || len(b) > 0
+		}
+	}
+
+	return r.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher, flushing the wrapped writer if it supports it
+//
+// Streaming handlers, e.g. server-sent events, keep working behind the middleware.
+func (r *responseRecorder) Flush() {
+	_ = http.NewResponseController(r.ResponseWriter).Flush()
+}
+
+// Hijack implements http.Hijacker, hijacking the wrapped writer's connection, e.g. for a websocket upgrade
+func (r *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(r.ResponseWriter).Hijack()
+}
+
+// Unwrap returns the wrapped writer, so http.ResponseController reaches its other methods
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,195 @@
+package logger
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestManager_Middleware(t *testing.T) {
+	core, recorded := observer.New(zapcore.DebugLevel)
+	logger := &Manager{Zap: zap.New(core)}
+
+	handler := logger.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/users", nil))
+
+	require.Equal(t, 1, recorded.Len())
+	fields := recorded.All()[0].ContextMap()
+	assert.Equal(t, "POST", fields["method"])
+	assert.Equal(t, "/users", fields["path"])
+	assert.Equal(t, int64(http.StatusCreated), fields["status"])
+}
+
+func TestManager_Middleware_BodyLogging(t *testing.T) {
+	skipWithoutDebug(t)
+
+	tests := []struct {
+		name         string
+		contentType  string
+		body         string
+		wantBodyLogs bool
+	}{
+		{"JSON body", "application/json; charset=utf-8", `{"name":"gopher","role":"admin"}`, true},
+		{"Binary body", "application/octet-stream", "\x00\x01\x02\x03", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, recorded := observer.New(zapcore.DebugLevel)
+			logger := &Manager{Zap: zap.New(core)}
+
+			var handlerSaw string
+			handler := logger.Middleware(WithBodyLogging(16))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				handlerSaw = string(body)
+				w.Header().Set("Content-Type", tt.contentType)
+				_, _ = w.Write(body)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.body, handlerSaw)
+			assert.Equal(t, tt.body, rec.Body.String())
+
+			bodyLogs := recorded.FilterMessage("http body").All()
+			if !tt.wantBodyLogs {
+				assert.Empty(t, bodyLogs)
+				return
+			}
+
+			require.Len(t, bodyLogs, 1)
+			fields := bodyLogs[0].ContextMap()
+			assert.Equal(t, tt.body[:16], fields["request_body"])
+			assert.Equal(t, true, fields["request_body_truncated"])
+			assert.Equal(t, tt.body[:16], fields["response_body"])
+			assert.Equal(t, true, fields["response_body_truncated"])
+		})
+	}
+}
+
+func TestManager_Middleware_BodyLoggingNegativeSize(t *testing.T) {
+	skipWithoutDebug(t)
+
+	core, recorded := observer.New(zapcore.DebugLevel)
+	logger := &Manager{Zap: zap.New(core)}
+
+	handler := logger.Middleware(WithBodyLogging(-1))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(`{"name":"gopher"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	require.NotPanics(t, func() { handler.ServeHTTP(rec, req) })
+
+	assert.Equal(t, `{"name":"gopher"}`, rec.Body.String())
+	bodyLogs := recorded.FilterMessage("http body").All()
+	require.Len(t, bodyLogs, 1)
+	fields := bodyLogs[0].ContextMap()
+	assert.Equal(t, "", fields["request_body"])
+	assert.Equal(t, true, fields["request_body_truncated"])
+	assert.Equal(t, "", fields["response_body"])
+	assert.Equal(t, true, fields["response_body_truncated"])
+}
+
+func TestManager_Middleware_IDHeaders(t *testing.T) {
+	tests := []struct {
+		name          string
+		headers       map[string]string
+		wantTraceID   interface{}
+		wantRequestID interface{}
+	}{
+		{"both headers", map[string]string{"X-Trace-ID": "trace-1", "X-Request-ID": "req-1"}, "trace-1", "req-1"},
+		{"trace header only", map[string]string{"X-Trace-ID": "trace-1"}, "trace-1", nil},
+		{"request header only", map[string]string{"X-Request-ID": "req-1"}, nil, "req-1"},
+		{"no headers", nil, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, recorded := observer.New(zapcore.DebugLevel)
+			logger := &Manager{Zap: zap.New(core)}
+
+			middleware := logger.Middleware(WithTraceIDHeader("X-Trace-ID"), WithRequestIDHeader("X-Request-ID"))
+			handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				logger.Info(r.Context(), "handling")
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			require.Equal(t, 2, recorded.Len())
+			for _, entry := range recorded.All() {
+				fields := entry.ContextMap()
+				assert.Equal(t, tt.wantTraceID, fields[TraceIDField], entry.Message)
+				assert.Equal(t, tt.wantRequestID, fields[RequestIDField], entry.Message)
+			}
+		})
+	}
+}
+
+// hijackRecorder is an httptest.ResponseRecorder supporting http.Hijacker
+type hijackRecorder struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+}
+
+// Hijack implements http.Hijacker, recording the call
+func (r *hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	r.hijacked = true
+	return nil, nil, nil
+}
+
+func TestManager_Middleware_Streaming(t *testing.T) {
+	logger := &Manager{Zap: zap.NewNop()}
+
+	handler := logger.Middleware(WithBodyLogging(16))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "data: 1\n\n")
+		flusher, ok := w.(http.Flusher)
+		require.True(t, ok)
+		flusher.Flush()
+		require.NoError(t, http.NewResponseController(w).Flush())
+
+		_, _, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+	}))
+
+	rec := &hijackRecorder{ResponseRecorder: httptest.NewRecorder()}
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events", nil))
+
+	assert.True(t, rec.Flushed)
+	assert.True(t, rec.hijacked)
+	assert.Equal(t, "data: 1\n\n", rec.Body.String())
+}
+
+func TestManager_Middleware_HijackNotSupported(t *testing.T) {
+	logger := &Manager{Zap: zap.NewNop()}
+
+	handler := logger.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _, err := http.NewResponseController(w).Hijack()
+		assert.ErrorIs(t, err, http.ErrNotSupported)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
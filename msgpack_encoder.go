@@ -0,0 +1,396 @@
+package logger
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// msgpackPool is the buffer pool used by the MessagePack encoder
+var msgpackPool = buffer.NewPool()
+
+// msgpackEncoder is a zapcore.Encoder that emits MessagePack-encoded entries
+//
+// Each entry is written as one frame: a 4-byte big-endian length followed by a
+// MessagePack map holding the entry metadata and its fields.
+type msgpackEncoder struct {
+	*zapcore.MapObjectEncoder
+	cfg        *zapcore.EncoderConfig
+	namespaces []string // Namespaces opened on the encoder, outermost first
+}
+
+// newMsgpackEncoder creates a new MessagePack encoder
+//
+// Parameters:
+//   - cfg: The encoder configuration providing keys and value encoders
+//
+// Returns:
+//   - zapcore.Encoder: A new MessagePack encoder
+func newMsgpackEncoder(cfg zapcore.EncoderConfig) zapcore.Encoder {
+	return &msgpackEncoder{
+		MapObjectEncoder: zapcore.NewMapObjectEncoder(),
+		cfg:              &cfg,
+	}
+}
+
+// OpenNamespace implements zapcore.ObjectEncoder
+func (e *msgpackEncoder) OpenNamespace(key string) {
+	e.namespaces = append(e.namespaces, key)
+	e.MapObjectEncoder.OpenNamespace(key)
+}
+
+// Clone implements zapcore.Encoder
+func (e *msgpackEncoder) Clone() zapcore.Encoder {
+	return e.clone()
+}
+
+// clone copies the accumulated fields, reopening the namespaces on the copy
+func (e *msgpackEncoder) clone() *msgpackEncoder {
+	dst := &msgpackEncoder{
+		MapObjectEncoder: zapcore.NewMapObjectEncoder(),
+		cfg:              e.cfg,
+	}
+
+	src := e.Fields
+	for i := 0; ; i++ {
+		for k, v := range src {
+			if i < len(e.namespaces) && k == e.namespaces[i] {
+				continue
+			}
+			_ = dst.AddReflected(k, v)
+		}
+		if i == len(e.namespaces) {
+			break
+		}
+		dst.OpenNamespace(e.namespaces[i])
+		src = src[e.namespaces[i]].(map[string]interface{})
+	}
+
+	return dst
+}
+
+// EncodeEntry implements zapcore.Encoder
+func (e *msgpackEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	meta := zapcore.NewMapObjectEncoder()
+	var metaKeys []string
+	addMeta := func(key string, encode func(zapcore.PrimitiveArrayEncoder)) {
+		if key == "" {
+			return
+		}
+		_ = meta.AddArray(key, zapcore.ArrayMarshalerFunc(func(arr zapcore.ArrayEncoder) error {
+			encode(arr)
+			return nil
+		}))
+		if values := meta.Fields[key].([]interface{}); len(values) > 0 {
+			meta.Fields[key] = values[0]
+			metaKeys = append(metaKeys, key)
+		} else {
+			delete(meta.Fields, key)
+		}
+	}
+
+	if e.cfg.EncodeTime != nil {
+		addMeta(e.cfg.TimeKey, func(arr zapcore.PrimitiveArrayEncoder) { e.cfg.EncodeTime(ent.Time, arr) })
+	}
+	if e.cfg.EncodeLevel != nil {
+		addMeta(e.cfg.LevelKey, func(arr zapcore.PrimitiveArrayEncoder) { e.cfg.EncodeLevel(ent.Level, arr) })
+	}
+	if ent.LoggerName != "" {
+		addMeta(e.cfg.NameKey, func(arr zapcore.PrimitiveArrayEncoder) {
+			if e.cfg.EncodeName != nil {
+				e.cfg.EncodeName(ent.LoggerName, arr)
+				return
+			}
+			zapcore.FullNameEncoder(ent.LoggerName, arr)
+		})
+	}
+	if ent.Caller.Defined {
+		if e.cfg.EncodeCaller != nil {
+			addMeta(e.cfg.CallerKey, func(arr zapcore.PrimitiveArrayEncoder) { e.cfg.EncodeCaller(ent.Caller, arr) })
+		}
+		addMeta(e.cfg.FunctionKey, func(arr zapcore.PrimitiveArrayEncoder) { arr.AppendString(ent.Caller.Function) })
+	}
+	addMeta(e.cfg.MessageKey, func(arr zapcore.PrimitiveArrayEncoder) { arr.AppendString(ent.Message) })
+	if ent.Stack != "" {
+		addMeta(e.cfg.StacktraceKey, func(arr zapcore.PrimitiveArrayEncoder) { arr.AppendString(ent.Stack) })
+	}
+
+	final := e.clone()
+	for _, f := range fields {
+		f.AddTo(final)
+	}
+
+	body := msgpackPool.Get()
+	defer body.Free()
+
+	w := msgpackWriter{buf: body, cfg: e.cfg}
+	w.writeMapHeader(len(metaKeys) + len(final.Fields))
+	for _, k := range metaKeys {
+		w.writeString(k)
+		w.writeValue(meta.Fields[k])
+	}
+	w.writeMapBody(final.Fields)
+
+	line := msgpackPool.Get()
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(body.Len()))
+	_, _ = line.Write(size[:])
+	_, _ = line.Write(body.Bytes())
+
+	return line, nil
+}
+
+// msgpackWriter serializes the values produced by zapcore.MapObjectEncoder as MessagePack
+type msgpackWriter struct {
+	buf *buffer.Buffer
+	cfg *zapcore.EncoderConfig
+}
+
+// writeValue writes any value stored by zapcore.MapObjectEncoder
+func (w msgpackWriter) writeValue(v interface{}) {
+	switch v := v.(type) {
+	case nil:
+		w.buf.AppendByte(0xc0)
+	case bool:
+		if v {
+			w.buf.AppendByte(0xc3)
+		} else {
+			w.buf.AppendByte(0xc2)
+		}
+	case int:
+		w.writeInt(int64(v))
+	case int64:
+		w.writeInt(v)
+	case int32:
+		w.writeInt(int64(v))
+	case int16:
+		w.writeInt(int64(v))
+	case int8:
+		w.writeInt(int64(v))
+	case uint:
+		w.writeUint(uint64(v))
+	case uint64:
+		w.writeUint(v)
+	case uint32:
+		w.writeUint(uint64(v))
+	case uint16:
+		w.writeUint(uint64(v))
+	case uint8:
+		w.writeUint(uint64(v))
+	case uintptr:
+		w.writeUint(uint64(v))
+	case float32:
+		w.buf.AppendByte(0xca)
+		w.writeUint32(math.Float32bits(v))
+	case float64:
+		w.buf.AppendByte(0xcb)
+		w.writeUint64(math.Float64bits(v))
+	case complex64, complex128:
+		w.writeString(fmt.Sprint(v))
+	case string:
+		w.writeString(v)
+	case []byte:
+		w.writeBinary(v)
+	case time.Time:
+		w.writeEncoded(func(arr zapcore.PrimitiveArrayEncoder) {
+			if w.cfg.EncodeTime == nil {
+				arr.AppendInt64(v.UnixNano())
+				return
+			}
+			w.cfg.EncodeTime(v, arr)
+		})
+	case time.Duration:
+		w.writeEncoded(func(arr zapcore.PrimitiveArrayEncoder) {
+			if w.cfg.EncodeDuration == nil {
+				arr.AppendInt64(int64(v))
+				return
+			}
+			w.cfg.EncodeDuration(v, arr)
+		})
+	case []interface{}:
+		w.writeArrayHeader(len(v))
+		for _, elem := range v {
+			w.writeValue(elem)
+		}
+	case map[string]interface{}:
+		w.writeMapHeader(len(v))
+		w.writeMapBody(v)
+	default:
+		w.writeReflected(v)
+	}
+}
+
+// writeMapBody writes the map's key/value pairs in sorted key order
+func (w msgpackWriter) writeMapBody(m map[string]interface{}) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		w.writeString(k)
+		w.writeValue(m[k])
+	}
+}
+
+// writeEncoded writes the single value produced by a zap primitive encoder
+func (w msgpackWriter) writeEncoded(encode func(zapcore.PrimitiveArrayEncoder)) {
+	enc := zapcore.NewMapObjectEncoder()
+	_ = enc.AddArray("v", zapcore.ArrayMarshalerFunc(func(arr zapcore.ArrayEncoder) error {
+		encode(arr)
+		return nil
+	}))
+
+	values := enc.Fields["v"].([]interface{})
+	if len(values) == 0 {
+		w.writeValue(nil)
+		return
+	}
+	w.writeValue(values[0])
+}
+
+// writeReflected writes an arbitrary value through its JSON representation
+func (w msgpackWriter) writeReflected(v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		w.writeString(fmt.Sprintf("%v", v))
+		return
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		w.writeString(string(data))
+		return
+	}
+	w.writeValue(generic)
+}
+
+// writeInt writes a signed integer using the smallest MessagePack representation
+func (w msgpackWriter) writeInt(v int64) {
+	switch {
+	case v >= 0:
+		w.writeUint(uint64(v))
+	case v >= -32:
+		w.buf.AppendByte(byte(v))
+	case v >= math.MinInt8:
+		w.buf.AppendByte(0xd0)
+		w.buf.AppendByte(byte(v))
+	case v >= math.MinInt16:
+		w.buf.AppendByte(0xd1)
+		w.writeUint16(uint16(v))
+	case v >= math.MinInt32:
+		w.buf.AppendByte(0xd2)
+		w.writeUint32(uint32(v))
+	default:
+		w.buf.AppendByte(0xd3)
+		w.writeUint64(uint64(v))
+	}
+}
+
+// writeUint writes an unsigned integer using the smallest MessagePack representation
+func (w msgpackWriter) writeUint(v uint64) {
+	switch {
+	case v <= 0x7f:
+		w.buf.AppendByte(byte(v))
+	case v <= math.MaxUint8:
+		w.buf.AppendByte(0xcc)
+		w.buf.AppendByte(byte(v))
+	case v <= math.MaxUint16:
+		w.buf.AppendByte(0xcd)
+		w.writeUint16(uint16(v))
+	case v <= math.MaxUint32:
+		w.buf.AppendByte(0xce)
+		w.writeUint32(uint32(v))
+	default:
+		w.buf.AppendByte(0xcf)
+		w.writeUint64(v)
+	}
+}
+
+// writeString writes a MessagePack str
+func (w msgpackWriter) writeString(s string) {
+	n := len(s)
+	switch {
+	case n <= 31:
+		w.buf.AppendByte(0xa0 | byte(n))
+	case n <= math.MaxUint8:
+		w.buf.AppendByte(0xd9)
+		w.buf.AppendByte(byte(n))
+	case n <= math.MaxUint16:
+		w.buf.AppendByte(0xda)
+		w.writeUint16(uint16(n))
+	default:
+		w.buf.AppendByte(0xdb)
+		w.writeUint32(uint32(n))
+	}
+	w.buf.AppendString(s)
+}
+
+// writeBinary writes a MessagePack bin
+func (w msgpackWriter) writeBinary(b []byte) {
+	n := len(b)
+	switch {
+	case n <= math.MaxUint8:
+		w.buf.AppendByte(0xc4)
+		w.buf.AppendByte(byte(n))
+	case n <= math.MaxUint16:
+		w.buf.AppendByte(0xc5)
+		w.writeUint16(uint16(n))
+	default:
+		w.buf.AppendByte(0xc6)
+		w.writeUint32(uint32(n))
+	}
+	_, _ = w.buf.Write(b)
+}
+
+// writeArrayHeader writes the header of a MessagePack array with n elements
+func (w msgpackWriter) writeArrayHeader(n int) {
+	switch {
+	case n <= 15:
+		w.buf.AppendByte(0x90 | byte(n))
+	case n <= math.MaxUint16:
+		w.buf.AppendByte(0xdc)
+		w.writeUint16(uint16(n))
+	default:
+		w.buf.AppendByte(0xdd)
+		w.writeUint32(uint32(n))
+	}
+}
+
+// writeMapHeader writes the header of a MessagePack map with n entries
+func (w msgpackWriter) writeMapHeader(n int) {
+	switch {
+	case n <= 15:
+		w.buf.AppendByte(0x80 | byte(n))
+	case n <= math.MaxUint16:
+		w.buf.AppendByte(0xde)
+		w.writeUint16(uint16(n))
+	default:
+		w.buf.AppendByte(0xdf)
+		w.writeUint32(uint32(n))
+	}
+}
+
+func (w msgpackWriter) writeUint16(v uint16) {
+	w.buf.AppendByte(byte(v >> 8))
+	w.buf.AppendByte(byte(v))
+}
+
+func (w msgpackWriter) writeUint32(v uint32) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], v)
+	_, _ = w.buf.Write(b[:])
+}
+
+func (w msgpackWriter) writeUint64(v uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	_, _ = w.buf.Write(b[:])
+}
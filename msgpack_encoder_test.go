@@ -0,0 +1,187 @@
+package logger
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// decodeMsgpackFrame decodes a single length-prefixed MessagePack frame
+func decodeMsgpackFrame(t *testing.T, frame []byte) map[string]interface{} {
+	require.GreaterOrEqual(t, len(frame), 4)
+	size := binary.BigEndian.Uint32(frame[:4])
+	require.Equal(t, int(size), len(frame)-4)
+
+	d := &msgpackDecoder{data: frame[4:]}
+	v, err := d.decode()
+	require.NoError(t, err)
+	require.Equal(t, len(d.data), d.pos, "trailing bytes in frame")
+
+	return v.(map[string]interface{})
+}
+
+// msgpackDecoder is a minimal MessagePack decoder covering the types the encoder emits
+type msgpackDecoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *msgpackDecoder) next(n int) []byte {
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b
+}
+
+func (d *msgpackDecoder) uint(n int) uint64 {
+	var v uint64
+	for _, b := range d.next(n) {
+		v = v<<8 | uint64(b)
+	}
+	return v
+}
+
+func (d *msgpackDecoder) decode() (interface{}, error) {
+	c := d.next(1)[0]
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c&0xe0 == 0xa0:
+		return string(d.next(int(c & 0x1f))), nil
+	case c&0xf0 == 0x90:
+		return d.array(int(c & 0x0f))
+	case c&0xf0 == 0x80:
+		return d.mapping(int(c & 0x0f))
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		return d.next(int(d.uint(1 << (c - 0xc4)))), nil
+	case 0xca:
+		return float64(math.Float32frombits(uint32(d.uint(4)))), nil
+	case 0xcb:
+		return math.Float64frombits(d.uint(8)), nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		return int64(d.uint(1 << (c - 0xcc))), nil
+	case 0xd0:
+		return int64(int8(d.uint(1))), nil
+	case 0xd1:
+		return int64(int16(d.uint(2))), nil
+	case 0xd2:
+		return int64(int32(d.uint(4))), nil
+	case 0xd3:
+		return int64(d.uint(8)), nil
+	case 0xd9, 0xda, 0xdb:
+		return string(d.next(int(d.uint(1 << (c - 0xd9))))), nil
+	case 0xdc, 0xdd:
+		return d.array(int(d.uint(2 << (c - 0xdc))))
+	case 0xde, 0xdf:
+		return d.mapping(int(d.uint(2 << (c - 0xde))))
+	}
+
+	return nil, errors.New("unsupported msgpack type")
+}
+
+func (d *msgpackDecoder) array(n int) (interface{}, error) {
+	arr := make([]interface{}, n)
+	for i := range arr {
+		v, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		arr[i] = v
+	}
+	return arr, nil
+}
+
+func (d *msgpackDecoder) mapping(n int) (interface{}, error) {
+	m := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		k, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		v, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		m[k.(string)] = v
+	}
+	return m, nil
+}
+
+func TestMsgpackEncoder_RoundTrip(t *testing.T) {
+	enc := newMsgpackEncoder(DefaultEncoderConfig)
+	enc.AddString("service", "billing")
+	enc.OpenNamespace("request")
+	enc.AddInt("attempt", 2)
+
+	ent := zapcore.Entry{
+		Level:   zapcore.WarnLevel,
+		Time:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Message: "charge declined",
+		Caller:  zapcore.NewEntryCaller(0, "/src/billing/charge.go", 42, true),
+	}
+	buf, err := enc.EncodeEntry(ent, []zapcore.Field{
+		zap.Int64("amount", -1500),
+		zap.Float64("ratio", 0.25),
+		zap.Bool("retry", true),
+		zap.Binary("raw", []byte{1, 2, 3}),
+		zap.Strings("tags", []string{"a", "b"}),
+		zap.Duration("elapsed", 1500*time.Millisecond),
+	})
+	require.NoError(t, err)
+	defer buf.Free()
+
+	got := decodeMsgpackFrame(t, buf.Bytes())
+	assert.Equal(t, "WARN", got["L"])
+	assert.Equal(t, "2024-01-02T03:04:05.000Z", got["T"])
+	assert.Equal(t, "charge declined", got["M"])
+	assert.Equal(t, "billing/charge.go:42", got["C"])
+	assert.Equal(t, "billing", got["service"])
+
+	request := got["request"].(map[string]interface{})
+	assert.Equal(t, int64(2), request["attempt"])
+	assert.Equal(t, int64(-1500), request["amount"])
+	assert.Equal(t, 0.25, request["ratio"])
+	assert.Equal(t, true, request["retry"])
+	assert.Equal(t, []byte{1, 2, 3}, request["raw"])
+	assert.Equal(t, []interface{}{"a", "b"}, request["tags"])
+	assert.Equal(t, 1.5, request["elapsed"])
+}
+
+func TestMsgpackEncoder_CloneIsolation(t *testing.T) {
+	enc := newMsgpackEncoder(DefaultEncoderConfig)
+	enc.OpenNamespace("ctx")
+	clone := enc.Clone()
+	clone.AddString("only", "clone")
+
+	buf, err := enc.EncodeEntry(zapcore.Entry{Message: "original"}, nil)
+	require.NoError(t, err)
+	defer buf.Free()
+
+	got := decodeMsgpackFrame(t, buf.Bytes())
+	assert.Empty(t, got["ctx"])
+}
+
+func TestNew_UnknownEncoding(t *testing.T) {
+	_, err := New(WithEncoding("xml"))
+	assert.Error(t, err)
+
+	_, err = New(WithEncoding("msgpack"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,124 @@
+package logger
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// ObserverMaxEntries is the number of entries retained by WithObserver
+const ObserverMaxEntries = 1000
+
+type (
+	// observedEntries retains the most recent entries in a ring
+	observedEntries struct {
+		mu      sync.Mutex
+		entries []observer.LoggedEntry // Ring of at most ObserverMaxEntries entries
+		next    int                    // Index overwritten by the next entry once the ring is full
+	}
+
+	// observerCore is a zapcore.Core recording the entries it receives
+	observerCore struct {
+		zapcore.LevelEnabler
+		levelChecked
+		context  []zapcore.Field // Fields added through With
+		recorded *observedEntries
+	}
+)
+
+// WithObserver retains the most recent entries in memory, e.g. for a debug endpoint
+//
+// The entries at or above level are recorded with their full structured context, up to
+// ObserverMaxEntries; older entries are discarded. Read them with Observed.
+//
+// Parameters:
+//   - level: The minimum level of the recorded entries
+//
+// Returns:
+//   - Option: A function that adds the observer to the option struct
+func WithObserver(level zapcore.Level) Option {
+	return func(o *option) {
+		o.observed = &observedEntries{}
+		o.extraCores = append(o.extraCores, &observerCore{LevelEnabler: level, recorded: o.observed})
+	}
+}
+
+// With adds structured context to the core
+func (c *observerCore) With(fields []zapcore.Field) zapcore.Core {
+	context := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	context = append(context, c.context...)
+	context = append(context, fields...)
+
+	return &observerCore{LevelEnabler: c.LevelEnabler, context: context, recorded: c.recorded}
+}
+
+// Check adds the core if the entry's level is recorded
+func (c *observerCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write records the entry with its context
+func (c *observerCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	context := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	context = append(context, c.context...)
+	context = append(context, fields...)
+
+	c.recorded.add(observer.LoggedEntry{Entry: ent, Context: context})
+	return nil
+}
+
+// Sync does nothing, the entries are kept in memory
+func (c *observerCore) Sync() error {
+	return nil
+}
+
+// add records an entry, overwriting the oldest one once the ring is full
+func (o *observedEntries) add(entry observer.LoggedEntry) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if len(o.entries) < ObserverMaxEntries {
+		o.entries = append(o.entries, entry)
+		return
+	}
+
+	o.entries[o.next] = entry
+	o.next = (o.next + 1) % ObserverMaxEntries
+}
+
+// all returns the recorded entries, oldest first
+func (o *observedEntries) all() []observer.LoggedEntry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	all := make([]observer.LoggedEntry, 0, len(o.entries))
+	all = append(all, o.entries[o.next:]...)
+	all = append(all, o.entries[:o.next]...)
+
+	return all
+}
+
+// Observed returns a snapshot of the entries retained by WithObserver
+//
+// The snapshot holds the most recent entries, oldest first, and does not change when more
+// entries are logged. It is empty when the observer is not enabled.
+//
+// Returns:
+//   - *observer.ObservedLogs: The retained entries
+func (m *Manager) Observed() *observer.ObservedLogs {
+	core, logs := observer.New(zap.LevelEnablerFunc(func(zapcore.Level) bool { return true }))
+
+	if opt := m.options(); opt != nil && opt.observed != nil {
+		for _, entry := range opt.observed.all() {
+			_ = core.Write(entry.Entry, entry.Context)
+		}
+	}
+
+	return logs
+}
@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithObserver(t *testing.T) {
+	logger, err := New(WithLevel("error"), WithObserver(zapcore.InfoLevel))
+	require.NoError(t, err)
+	defer logger.Close()
+
+	ctx := context.WithValue(context.Background(), TraceIDKey, "trace-1")
+	logger.Log(ctx, DebugLevel, "not recorded")
+	logger.Info(ctx, "recorded", zap.Int("attempt", 2))
+
+	observed := logger.Observed()
+	require.Equal(t, 1, observed.Len())
+	entry := observed.All()[0]
+	assert.Equal(t, "recorded", entry.Message)
+	assert.Equal(t, map[string]interface{}{TraceIDField: "trace-1", "attempt": int64(2)}, entry.ContextMap())
+
+	logger.Warn(ctx, "later")
+	assert.Equal(t, 1, observed.Len(), "a snapshot does not change")
+	assert.Equal(t, 2, logger.Observed().Len())
+}
+
+func TestWithObserver_Capped(t *testing.T) {
+	logger, err := New(WithLevel("error"), WithObserver(zapcore.InfoLevel))
+	require.NoError(t, err)
+	defer logger.Close()
+
+	for i := 0; i < ObserverMaxEntries+10; i++ {
+		logger.Info(context.Background(), fmt.Sprintf("entry %d", i))
+	}
+
+	entries := logger.Observed().All()
+	require.Len(t, entries, ObserverMaxEntries)
+	assert.Equal(t, "entry 10", entries[0].Message)
+	assert.Equal(t, fmt.Sprintf("entry %d", ObserverMaxEntries+9), entries[len(entries)-1].Message)
+}
+
+func TestManager_Observed_Disabled(t *testing.T) {
+	logger := &Manager{Zap: zap.NewNop()}
+	assert.Equal(t, 0, logger.Observed().Len())
+}
@@ -0,0 +1,399 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	otlpQueueSize     = 2048        // Maximum number of records waiting to be exported
+	otlpBatchSize     = 512         // Maximum number of records per export request
+	otlpFlushInterval = time.Second // Maximum time a record waits for its batch to fill
+	otlpScopeName     = "github.com/sk-pkg/logger"
+)
+
+type (
+	// otlpExporter sends log records to an OTLP/HTTP endpoint in batches from a background goroutine
+	otlpExporter struct {
+		url     string
+		client  *http.Client
+		queue   chan otlpLogRecord
+		flush   chan chan struct{} // Requests an immediate export, closed channel acknowledges it
+		done    chan struct{}      // Closed to stop the exporter
+		once    sync.Once          // Closes done once
+		stopped chan struct{}      // Closed once the exporter has stopped
+		dropped atomic.Uint64      // Records dropped because the queue was full
+	}
+
+	// otlpCore is a zapcore.Core converting entries to OTLP log records
+	otlpCore struct {
+		zapcore.LevelEnabler
+		levelChecked
+		exporter *otlpExporter
+		context  []zapcore.Field // Fields added through With
+	}
+
+	// otlpLogRecord is a LogRecord in the OTLP/JSON encoding
+	otlpLogRecord struct {
+		TimeUnixNano         string         `json:"timeUnixNano"`
+		ObservedTimeUnixNano string         `json:"observedTimeUnixNano"`
+		SeverityNumber       int            `json:"severityNumber"`
+		SeverityText         string         `json:"severityText"`
+		Body                 otlpAnyValue   `json:"body"`
+		Attributes           []otlpKeyValue `json:"attributes,omitempty"`
+		TraceID              string         `json:"traceId,omitempty"`
+		SpanID               string         `json:"spanId,omitempty"`
+	}
+
+	// otlpKeyValue is a KeyValue in the OTLP/JSON encoding
+	otlpKeyValue struct {
+		Key   string       `json:"key"`
+		Value otlpAnyValue `json:"value"`
+	}
+
+	// otlpAnyValue is an AnyValue in the OTLP/JSON encoding, holding exactly one of its keys
+	otlpAnyValue map[string]interface{}
+)
+
+// WithOTLP exports the entries as OpenTelemetry log records to an OTLP/HTTP endpoint
+//
+// The entries are converted to LogRecords, with the level mapped to the severity, the
+// message as the body and the fields as attributes; a TraceID made of 32 hex digits becomes
+// the record's trace ID and the SpanID set by StartSpan its span ID. Records are sent as
+// OTLP/JSON to endpoint + "/v1/logs" in batches by a background goroutine, in addition to
+// the driver's output. Logging never blocks on the export: when the bounded queue is full,
+// records are dropped. Sync exports the queued records and Close stops the exporter after a
+// final export. Only the standard library is used, so the package does not depend on the
+// OpenTelemetry SDK.
+//
+// Parameters:
+//   - endpoint: The base URL of the OTLP/HTTP receiver, e.g. "http://localhost:4318"
+//
+// Returns:
+//   - Option: A function that sets the OTLP endpoint in the option struct
+func WithOTLP(endpoint string) Option {
+	return func(o *option) {
+		o.otlpEndpoint = endpoint
+	}
+}
+
+// newOTLPExporter starts an exporter sending to the logs path of endpoint
+//
+// Parameters:
+//   - endpoint: The base URL of the OTLP/HTTP receiver
+//
+// Returns:
+//   - *otlpExporter: The running exporter, stopped by Close
+func newOTLPExporter(endpoint string) *otlpExporter {
+	url := strings.TrimSuffix(endpoint, "/")
+	if !strings.HasSuffix(url, "/v1/logs") {
+		url += "/v1/logs"
+	}
+
+	e := &otlpExporter{
+		url:     url,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		queue:   make(chan otlpLogRecord, otlpQueueSize),
+		flush:   make(chan chan struct{}),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go e.run()
+
+	return e
+}
+
+// run batches the queued records until the exporter is closed
+func (e *otlpExporter) run() {
+	defer close(e.stopped)
+
+	ticker := time.NewTicker(otlpFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]otlpLogRecord, 0, otlpBatchSize)
+	for {
+		select {
+		case record := <-e.queue:
+			batch = append(batch, record)
+			if len(batch) >= otlpBatchSize {
+				batch = e.export(batch)
+			}
+		case <-ticker.C:
+			batch = e.export(batch)
+		case ack := <-e.flush:
+			batch = e.export(e.drain(batch))
+			close(ack)
+		case <-e.done:
+			e.export(e.drain(batch))
+			return
+		}
+	}
+}
+
+// drain appends the records waiting in the queue to the batch
+func (e *otlpExporter) drain(batch []otlpLogRecord) []otlpLogRecord {
+	for {
+		select {
+		case record := <-e.queue:
+			batch = append(batch, record)
+		default:
+			return batch
+		}
+	}
+}
+
+// export sends the batch in requests of at most otlpBatchSize records and returns it emptied
+func (e *otlpExporter) export(batch []otlpLogRecord) []otlpLogRecord {
+	for start := 0; start < len(batch); start += otlpBatchSize {
+		end := min(start+otlpBatchSize, len(batch))
+		if err := e.send(batch[start:end]); err != nil {
+			_, _ = os.Stderr.WriteString("failed to export logs: " + err.Error() + "\n")
+		}
+	}
+
+	return batch[:0]
+}
+
+// send posts the records as one ExportLogsServiceRequest
+func (e *otlpExporter) send(records []otlpLogRecord) error {
+	request := map[string]interface{}{
+		"resourceLogs": []interface{}{map[string]interface{}{
+			"resource": map[string]interface{}{},
+			"scopeLogs": []interface{}{map[string]interface{}{
+				"scope":      map[string]interface{}{"name": otlpScopeName},
+				"logRecords": records,
+			}},
+		}},
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	resp, err := e.client.Post(e.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s from %s", resp.Status, e.url)
+	}
+
+	return nil
+}
+
+// enqueue queues a record for export, dropping it if the queue is full
+func (e *otlpExporter) enqueue(record otlpLogRecord) {
+	select {
+	case e.queue <- record:
+	default:
+		e.dropped.Add(1)
+	}
+}
+
+// Sync exports the queued records and waits for the export to finish
+func (e *otlpExporter) Sync() error {
+	ack := make(chan struct{})
+	select {
+	case e.flush <- ack:
+		<-ack
+	case <-e.stopped:
+	}
+
+	return nil
+}
+
+// Close stops the exporter after exporting the queued records
+func (e *otlpExporter) Close() error {
+	e.once.Do(func() { close(e.done) })
+	<-e.stopped
+
+	return nil
+}
+
+// With adds structured context to the core
+func (c *otlpCore) With(fields []zapcore.Field) zapcore.Core {
+	context := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	context = append(context, c.context...)
+	context = append(context, fields...)
+
+	return &otlpCore{LevelEnabler: c.LevelEnabler, exporter: c.exporter, context: context}
+}
+
+// Check adds the core if the entry's level is enabled
+func (c *otlpCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write converts the entry to a log record and queues it for export
+func (c *otlpCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.context {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	record := otlpLogRecord{
+		TimeUnixNano:         strconv.FormatInt(ent.Time.UnixNano(), 10),
+		ObservedTimeUnixNano: strconv.FormatInt(time.Now().UnixNano(), 10),
+		SeverityNumber:       otlpSeverity(ent.Level),
+		SeverityText:         strings.ToUpper(levelName(ent.Level)),
+		Body:                 otlpAnyValue{"stringValue": ent.Message},
+	}
+
+	if traceID, ok := enc.Fields[TraceIDField].(string); ok && isOTLPTraceID(traceID) {
+		record.TraceID = strings.ToLower(traceID)
+		delete(enc.Fields, TraceIDField)
+	}
+	if spanID, ok := enc.Fields[SpanIDField].(string); ok && isOTLPSpanID(spanID) {
+		record.SpanID = strings.ToLower(spanID)
+		delete(enc.Fields, SpanIDField)
+	}
+
+	if ent.LoggerName != "" {
+		enc.Fields["logger.name"] = ent.LoggerName
+	}
+	if ent.Caller.Defined {
+		enc.Fields["code.filepath"] = ent.Caller.File
+		enc.Fields["code.lineno"] = int64(ent.Caller.Line)
+		if ent.Caller.Function != "" {
+			enc.Fields["code.function"] = ent.Caller.Function
+		}
+	}
+	if ent.Stack != "" {
+		enc.Fields["exception.stacktrace"] = ent.Stack
+	}
+
+	record.Attributes = otlpAttributes(enc.Fields)
+	c.exporter.enqueue(record)
+
+	return nil
+}
+
+// Sync exports the queued records
+func (c *otlpCore) Sync() error {
+	return c.exporter.Sync()
+}
+
+// otlpSeverity maps a level to an OpenTelemetry severity number
+func otlpSeverity(level zapcore.Level) int {
+	switch {
+	case level <= TraceLevel:
+		return 1 // TRACE
+	case level == zapcore.DebugLevel:
+		return 5 // DEBUG
+	case level == zapcore.InfoLevel:
+		return 9 // INFO
+	case level == zapcore.WarnLevel:
+		return 13 // WARN
+	case level == zapcore.ErrorLevel:
+		return 17 // ERROR
+	case level == zapcore.DPanicLevel, level == zapcore.PanicLevel:
+		return 18 // ERROR2
+	default:
+		return 21 // FATAL
+	}
+}
+
+// isOTLPTraceID reports whether id is a valid trace ID of 16 bytes in hex
+func isOTLPTraceID(id string) bool {
+	b, err := hex.DecodeString(id)
+	return err == nil && len(b) == 16 && strings.Trim(id, "0") != ""
+}
+
+// isOTLPSpanID reports whether id is a valid span ID of 8 bytes in hex
+func isOTLPSpanID(id string) bool {
+	b, err := hex.DecodeString(id)
+	return err == nil && len(b) == 8 && strings.Trim(id, "0") != ""
+}
+
+// otlpAttributes converts encoded fields to attributes, sorted by key
+func otlpAttributes(fields map[string]interface{}) []otlpKeyValue {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attributes := make([]otlpKeyValue, 0, len(keys))
+	for _, k := range keys {
+		attributes = append(attributes, otlpKeyValue{Key: k, Value: otlpValue(fields[k])})
+	}
+
+	return attributes
+}
+
+// otlpValue converts a value produced by zapcore.MapObjectEncoder to an AnyValue
+func otlpValue(v interface{}) otlpAnyValue {
+	switch v := v.(type) {
+	case string:
+		return otlpAnyValue{"stringValue": v}
+	case bool:
+		return otlpAnyValue{"boolValue": v}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr:
+		return otlpAnyValue{"intValue": fmt.Sprint(v)}
+	case float32:
+		return otlpAnyValue{"doubleValue": float64(v)}
+	case float64:
+		return otlpAnyValue{"doubleValue": v}
+	case []byte:
+		return otlpAnyValue{"bytesValue": base64.StdEncoding.EncodeToString(v)}
+	case time.Time:
+		return otlpAnyValue{"stringValue": v.Format(time.RFC3339Nano)}
+	case time.Duration:
+		return otlpAnyValue{"stringValue": v.String()}
+	case []interface{}:
+		values := make([]otlpAnyValue, 0, len(v))
+		for _, item := range v {
+			values = append(values, otlpValue(item))
+		}
+		return otlpAnyValue{"arrayValue": map[string]interface{}{"values": values}}
+	case map[string]interface{}:
+		return otlpAnyValue{"kvlistValue": map[string]interface{}{"values": otlpAttributes(v)}}
+	case nil:
+		return otlpAnyValue{}
+	default:
+		return otlpAnyValue{"stringValue": fmt.Sprint(v)}
+	}
+}
+
+// newOTLPCore creates the core exporting to the endpoint set with WithOTLP
+//
+// The exporter is started once and reused when the core is rebuilt.
+//
+// Parameters:
+//   - opt: The option struct containing configuration
+//   - level: The zap.AtomicLevel for dynamic level changes
+//
+// Returns:
+//   - zapcore.Core: A core queuing the entries for export
+func newOTLPCore(opt *option, level zap.AtomicLevel) zapcore.Core {
+	if opt.otlp == nil {
+		opt.otlp = newOTLPExporter(opt.otlpEndpoint)
+		opt.closers = append(opt.closers, opt.otlp.Close)
+	}
+
+	return &otlpCore{LevelEnabler: level, exporter: opt.otlp}
+}
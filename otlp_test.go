@@ -0,0 +1,120 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// otlpReceiver is a mock OTLP/HTTP receiver collecting the exported log records
+type otlpReceiver struct {
+	mu      sync.Mutex
+	paths   []string
+	records []map[string]interface{}
+}
+
+func (r *otlpReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var body struct {
+		ResourceLogs []struct {
+			ScopeLogs []struct {
+				Scope      map[string]interface{}   `json:"scope"`
+				LogRecords []map[string]interface{} `json:"logRecords"`
+			} `json:"scopeLogs"`
+		} `json:"resourceLogs"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.paths = append(r.paths, req.URL.Path)
+	for _, resource := range body.ResourceLogs {
+		for _, scope := range resource.ScopeLogs {
+			r.records = append(r.records, scope.LogRecords...)
+		}
+	}
+}
+
+// attributes returns the attributes of a decoded record keyed by name
+func attributes(record map[string]interface{}) map[string]interface{} {
+	attrs := map[string]interface{}{}
+	for _, a := range record["attributes"].([]interface{}) {
+		kv := a.(map[string]interface{})
+		attrs[kv["key"].(string)] = kv["value"]
+	}
+	return attrs
+}
+
+func TestWithOTLP(t *testing.T) {
+	receiver := &otlpReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	logger, err := New(WithPlainFile(filepath.Join(t.TempDir(), "app.log")), WithOTLP(server.URL))
+	require.NoError(t, err)
+	defer logger.Close()
+
+	ctx := context.WithValue(context.Background(), TraceIDKey, "4BF92F3577B34DA6A3CE929D0E0E4736")
+	logger.Warn(ctx, "stock low", zap.String("sku", "A-1"), zap.Int("left", 2))
+	logger.Debug(ctx, "not enabled")
+	require.NoError(t, logger.Sync())
+
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	assert.Equal(t, []string{"/v1/logs"}, receiver.paths)
+	require.Len(t, receiver.records, 1)
+
+	record := receiver.records[0]
+	assert.Equal(t, float64(13), record["severityNumber"])
+	assert.Equal(t, "WARN", record["severityText"])
+	assert.Equal(t, map[string]interface{}{"stringValue": "stock low"}, record["body"])
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", record["traceId"])
+
+	attrs := attributes(record)
+	assert.Equal(t, map[string]interface{}{"stringValue": "A-1"}, attrs["sku"])
+	assert.Equal(t, map[string]interface{}{"intValue": "2"}, attrs["left"])
+	assert.Contains(t, attrs, "code.filepath")
+	assert.NotContains(t, attrs, TraceIDField)
+}
+
+func TestWithOTLP_CloseTwice(t *testing.T) {
+	server := httptest.NewServer(&otlpReceiver{})
+	defer server.Close()
+
+	logger, err := New(WithPlainFile(filepath.Join(t.TempDir(), "app.log")), WithOTLP(server.URL))
+	require.NoError(t, err)
+
+	assert.NoError(t, logger.Close())
+	assert.NotPanics(t, func() { _ = logger.Close() })
+}
+
+func TestOTLPExporter_QueueFull(t *testing.T) {
+	e := &otlpExporter{queue: make(chan otlpLogRecord, 1)}
+	e.enqueue(otlpLogRecord{})
+	e.enqueue(otlpLogRecord{})
+	assert.Equal(t, uint64(1), e.dropped.Load())
+}
+
+func TestOTLPValue(t *testing.T) {
+	assert.Equal(t, otlpAnyValue{"boolValue": true}, otlpValue(true))
+	assert.Equal(t, otlpAnyValue{"doubleValue": 0.5}, otlpValue(0.5))
+	assert.Equal(t, otlpAnyValue{"intValue": "7"}, otlpValue(uint64(7)))
+	assert.Equal(t,
+		otlpAnyValue{"kvlistValue": map[string]interface{}{"values": []otlpKeyValue{{Key: "a", Value: otlpAnyValue{"stringValue": "b"}}}}},
+		otlpValue(map[string]interface{}{"a": "b"}),
+	)
+	assert.False(t, isOTLPTraceID("not-a-trace-id"))
+	assert.False(t, isOTLPTraceID("00000000000000000000000000000000"))
+	assert.True(t, isOTLPSpanID("00f067aa0ba902b7"))
+	assert.False(t, isOTLPSpanID("4bf92f3577b34da6a3ce929d0e0e4736"))
+}
@@ -0,0 +1,121 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// panicHook runs a user function between writing a Panic entry and panicking
+type panicHook struct {
+	core zapcore.Core                         // Core flushed before the hook runs
+	fn   func(msg string, fields []zap.Field) // User function to run
+}
+
+// continueHook is a zapcore.CheckWriteHook that flushes the core and lets execution continue
+type continueHook struct {
+	core zapcore.Core // Core flushed after a Fatal entry
+}
+
+// WithPanicHook sets a function called when a Panic entry is logged, right before panicking
+//
+// The hook runs after the entry has been written and the outputs flushed, so it can perform
+// cleanup such as flushing metrics or sending notifications. The panic still propagates
+// once the hook returns. Fatal entries are not affected.
+//
+// Parameters:
+//   - hook: The function called with the entry's message and fields
+//
+// Returns:
+//   - Option: A function that sets the panic hook in the option struct
+func WithPanicHook(hook func(msg string, fields []zap.Field)) Option {
+	return func(o *option) {
+		o.panicHook = hook
+	}
+}
+
+// WithExitOnFatal sets whether Fatal entries terminate the process, true by default
+//
+// Library code embedded in a host process must not call os.Exit. When disabled, Fatal and
+// Log at FatalLevel still write the entry at FatalLevel and flush the outputs, then return
+// to the caller. The caller must handle the failure itself, since execution continues
+// after the call.
+//
+// Parameters:
+//   - exit: Whether Fatal calls os.Exit(1) after logging
+//
+// Returns:
+//   - Option: A function that sets the fatal behavior in the option struct
+func WithExitOnFatal(exit bool) Option {
+	return func(o *option) {
+		o.noExitOnFatal = !exit
+	}
+}
+
+// OnWrite flushes the core and returns
+func (h continueHook) OnWrite(*zapcore.CheckedEntry, []zapcore.Field) {
+	_ = h.core.Sync()
+}
+
+// OnWrite flushes the core, runs the hook and panics
+func (h panicHook) OnWrite(ce *zapcore.CheckedEntry, fields []zapcore.Field) {
+	_ = h.core.Sync()
+	h.fn(ce.Message, fields)
+	zapcore.WriteThenPanic.OnWrite(ce, fields)
+}
+
+// RecoverAndLog recovers a panic and logs it at Error level, to be deferred directly
+//
+// The entry has the recovered value as "panic_value", its Go type as "panic_type", e.g.
+// "string", "*errors.errorString" or "runtime.boundsError", and the stack trace of the
+// panic, whatever the level set by WithStacktraceLevel. The caller is the line that
+// panicked. Execution continues after the deferring function returns; nothing is logged
+// when no panic occurred. The function must be deferred itself, as in
+// defer logger.RecoverAndLog(ctx, "job failed"), since recover has no effect in a function
+// called by the deferred one.
+//
+// Parameters:
+//   - ctx: The context.Context for this log entry
+//   - msg: The message to log
+//   - fields: Optional fields to add to the log entry
+func (m *Manager) RecoverAndLog(ctx context.Context, msg string, fields ...zap.Field) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	fields = append(fields[:len(fields):len(fields)],
+		zap.String("panic_value", fmt.Sprint(r)),
+		zap.String("panic_type", fmt.Sprintf("%T", r)),
+	)
+	logger := m.getLoggerWithTraceID(ctx, fields...)
+	if ce := logger.WithOptions(zap.AddStacktrace(ErrorLevel)).Check(ErrorLevel, msg); ce != nil {
+		// zap's caller would be the runtime's panic function, use the frame that panicked
+		if ce.Caller.Defined {
+			ce.Caller = panicCaller()
+		}
+		ce.Write(fields...)
+	}
+}
+
+// panicCaller returns the frame that panicked, as seen from RecoverAndLog
+//
+// Returns:
+//   - zapcore.EntryCaller: The first frame below RecoverAndLog outside of the runtime
+func panicCaller() zapcore.EntryCaller {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)]) // Skip Callers, panicCaller and RecoverAndLog
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "runtime.") {
+			return zapcore.EntryCaller{Defined: true, PC: frame.PC, File: frame.File, Line: frame.Line, Function: frame.Function}
+		}
+		if !more {
+			return zapcore.EntryCaller{}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithPanicHook(t *testing.T) {
+	var hookMsg string
+	var hookFields []zap.Field
+	var hookCalls, writtenBeforeHook int
+
+	core, recorded := observer.New(zapcore.PanicLevel)
+	logger, err := New(WithExtraCore(core), WithPanicHook(func(msg string, fields []zap.Field) {
+		hookCalls++
+		writtenBeforeHook = recorded.Len()
+		hookMsg = msg
+		hookFields = fields
+	}))
+	require.NoError(t, err)
+
+	recovered := func() (r interface{}) {
+		defer func() { r = recover() }()
+		logger.Panic(context.Background(), "out of memory", zap.Int("heap_mb", 512))
+		return nil
+	}()
+
+	assert.Equal(t, "out of memory", recovered)
+	assert.Equal(t, 1, hookCalls)
+	assert.Equal(t, 1, writtenBeforeHook)
+	assert.Equal(t, "out of memory", hookMsg)
+	assert.Equal(t, []zap.Field{zap.Int("heap_mb", 512)}, hookFields)
+
+	// Other levels do not run the hook
+	logger.Error(context.Background(), "not a panic")
+	assert.Equal(t, 1, hookCalls)
+}
+
+func TestWithExitOnFatal(t *testing.T) {
+	core, recorded := observer.New(zapcore.FatalLevel)
+	logger, err := New(WithExtraCore(core), WithExitOnFatal(false))
+	require.NoError(t, err)
+
+	logger.Fatal(context.Background(), "cannot load plugin", zap.String("plugin", "auth"))
+	logger.Log(context.Background(), zapcore.FatalLevel, "cannot load plugin again")
+
+	entries := recorded.All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, zapcore.FatalLevel, entries[0].Level)
+	assert.Equal(t, "cannot load plugin", entries[0].Message)
+	assert.Equal(t, "auth", entries[0].ContextMap()["plugin"])
+}
+
+func TestManager_RecoverAndLog(t *testing.T) {
+	core, recorded := observer.New(zapcore.ErrorLevel)
+	logger, err := New(WithExtraCore(core))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		fn        func()
+		wantValue string
+		wantType  string
+	}{
+		{"string", func() { panic("boom") }, "boom", "string"},
+		{"error", func() { panic(errors.New("disk full")) }, "disk full", "*errors.errorString"},
+		{"runtime error", func() {
+			var s []int
+			_ = s[len(s)]
+		}, "runtime error: index out of range [0] with length 0", "runtime.boundsError"},
+		{"int", func() { panic(42) }, "42", "int"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			func() {
+				defer logger.RecoverAndLog(context.Background(), "job failed", zap.String("job", tt.name))
+				tt.fn()
+			}()
+
+			entries := recorded.TakeAll()
+			require.Len(t, entries, 1)
+			assert.Equal(t, zapcore.ErrorLevel, entries[0].Level)
+			assert.Equal(t, "job failed", entries[0].Message)
+			fields := entries[0].ContextMap()
+			assert.Equal(t, tt.name, fields["job"])
+			assert.Equal(t, tt.wantValue, fields["panic_value"])
+			assert.Equal(t, tt.wantType, fields["panic_type"])
+			assert.Contains(t, entries[0].Stack, "TestManager_RecoverAndLog")
+			assert.Contains(t, entries[0].Caller.File, "panic_test.go", "the caller is the frame that panicked")
+		})
+	}
+
+	// Without a panic, nothing is logged
+	func() {
+		defer logger.RecoverAndLog(context.Background(), "job failed")
+	}()
+	assert.Zero(t, recorded.Len())
+}
@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"go.uber.org/zap/zapcore"
+)
+
+// messagePrefixCore is a zapcore.Core prepending a static prefix to every message
+type messagePrefixCore struct {
+	zapcore.Core
+	levelChecked
+	prefix string
+}
+
+// WithMessagePrefix prepends a static prefix to every message
+//
+// The prefix is added as is, so include the separator, e.g. "[payments] " turns
+// "charge succeeded" into "[payments] charge succeeded" for pipelines keying off a leading
+// tag. It is added once per entry, also for loggers derived through With and Named.
+// Filters and samplers see the message without the prefix.
+//
+// Parameters:
+//   - prefix: The prefix of every message
+//
+// Returns:
+//   - Option: A function that sets the message prefix in the option struct
+func WithMessagePrefix(prefix string) Option {
+	return func(o *option) {
+		o.messagePrefix = prefix
+	}
+}
+
+// newMessagePrefixCore wraps a core to prepend a prefix to every message
+//
+// Parameters:
+//   - core: The core to wrap
+//   - prefix: The prefix of every message
+//
+// Returns:
+//   - zapcore.Core: A core prepending the prefix
+func newMessagePrefixCore(core zapcore.Core, prefix string) zapcore.Core {
+	return &messagePrefixCore{Core: core, prefix: prefix}
+}
+
+// With adds structured context to the core
+func (c *messagePrefixCore) With(fields []zapcore.Field) zapcore.Core {
+	return &messagePrefixCore{Core: c.Core.With(fields), prefix: c.prefix}
+}
+
+// Check adds the core so Write can change the message
+func (c *messagePrefixCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write prepends the prefix to the message
+func (c *messagePrefixCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent.Message = c.prefix + ent.Message
+	return writeEntry(c.Core, ent, fields)
+}
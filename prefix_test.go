@@ -0,0 +1,28 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithMessagePrefix(t *testing.T) {
+	opt := &option{}
+	WithMessagePrefix("[payments] ")(opt)
+
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger := zap.New(wrapCore(opt, core))
+
+	logger.Info("charge succeeded")
+	logger.With(zap.String("order", "o-1")).Named("stripe").Warn("charge retried")
+	logger.Debug("not enabled")
+
+	var messages []string
+	for _, entry := range recorded.All() {
+		messages = append(messages, entry.Message)
+	}
+	assert.Equal(t, []string{"[payments] charge succeeded", "[payments] charge retried"}, messages)
+}
@@ -0,0 +1,144 @@
+package logger
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	// tokenBucket limits the rate of the entries of one level
+	tokenBucket struct {
+		mu      sync.Mutex
+		rate    float64   // Tokens added per second, also the bucket capacity
+		tokens  float64   // Tokens currently available
+		last    time.Time // Time of the last refill, zero before the first entry
+		dropped atomic.Uint64
+	}
+
+	// rateLimitCore is a zapcore.Core dropping the entries exceeding the rate of their level
+	rateLimitCore struct {
+		zapcore.Core
+		buckets map[zapcore.Level]*tokenBucket
+	}
+)
+
+// WithRateLimitByLevel caps the number of entries per second of each level independently
+//
+// Every level in limits gets a token bucket refilled at limit tokens per second, which
+// allows bursts of up to limit entries. Entries arriving while the bucket of their level
+// is empty are dropped and counted, see RateLimitDropped. Levels absent from limits are
+// unlimited, a limit of 0 drops every entry of the level.
+//
+// Parameters:
+//   - limits: The maximum number of entries per second, keyed by level
+//
+// Returns:
+//   - Option: A function that sets the rate limits in the option struct
+func WithRateLimitByLevel(limits map[zapcore.Level]int) Option {
+	return func(o *option) {
+		o.rateLimits = limits
+	}
+}
+
+// newRateLimitCore wraps a core to rate limit the entries of the given levels
+//
+// Parameters:
+//   - core: The core to wrap
+//   - buckets: The token buckets, keyed by level
+//
+// Returns:
+//   - zapcore.Core: A core dropping the entries exceeding the rate of their level
+func newRateLimitCore(core zapcore.Core, buckets map[zapcore.Level]*tokenBucket) zapcore.Core {
+	return &rateLimitCore{Core: core, buckets: buckets}
+}
+
+// newTokenBuckets creates a full token bucket per limited level
+//
+// Parameters:
+//   - limits: The maximum number of entries per second, keyed by level
+//
+// Returns:
+//   - map[zapcore.Level]*tokenBucket: The token buckets, keyed by level
+func newTokenBuckets(limits map[zapcore.Level]int) map[zapcore.Level]*tokenBucket {
+	buckets := make(map[zapcore.Level]*tokenBucket, len(limits))
+	for level, limit := range limits {
+		rate := float64(max(limit, 0))
+		buckets[level] = &tokenBucket{rate: rate, tokens: rate}
+	}
+
+	return buckets
+}
+
+// With adds structured context to the core, sharing the token buckets
+func (c *rateLimitCore) With(fields []zapcore.Field) zapcore.Core {
+	return &rateLimitCore{Core: c.Core.With(fields), buckets: c.buckets}
+}
+
+// Check drops the entry if the bucket of its level is empty
+func (c *rateLimitCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	if bucket, ok := c.buckets[ent.Level]; ok && !bucket.take(ent.Time) {
+		bucket.dropped.Add(1)
+		return ce
+	}
+
+	return c.Core.Check(ent, ce)
+}
+
+// checkWrite implements checkedWriter, dropping the entry as Check does
+func (c *rateLimitCore) checkWrite(ent zapcore.Entry, fields []zapcore.Field) error {
+	if !c.Enabled(ent.Level) {
+		return nil
+	}
+
+	if bucket, ok := c.buckets[ent.Level]; ok && !bucket.take(ent.Time) {
+		bucket.dropped.Add(1)
+		return nil
+	}
+
+	return writeEntry(c.Core, ent, fields)
+}
+
+// take refills the bucket up to t and consumes a token, reporting whether one was available
+func (b *tokenBucket) take(t time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.last.IsZero() && t.After(b.last) {
+		b.tokens = min(b.rate, b.tokens+t.Sub(b.last).Seconds()*b.rate)
+	}
+	if t.After(b.last) {
+		b.last = t
+	}
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+
+	return true
+}
+
+// RateLimitDropped returns the number of entries dropped by WithRateLimitByLevel
+//
+// Returns:
+//   - map[zapcore.Level]uint64: The number of dropped entries since the manager was created, keyed by level
+func (m *Manager) RateLimitDropped() map[zapcore.Level]uint64 {
+	dropped := make(map[zapcore.Level]uint64)
+	opt := m.options()
+	if opt == nil {
+		return dropped
+	}
+
+	for level, bucket := range opt.rateLimiters {
+		dropped[level] = bucket.dropped.Load()
+	}
+
+	return dropped
+}
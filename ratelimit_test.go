@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithRateLimitByLevel(t *testing.T) {
+	opt := &option{}
+	WithRateLimitByLevel(map[zapcore.Level]int{DebugLevel: 100, InfoLevel: 1000})(opt)
+
+	inner, recorded := observer.New(DebugLevel)
+	core := wrapCore(opt, inner)
+
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	write := func(level zapcore.Level, n int, at time.Time) {
+		for i := 0; i < n; i++ {
+			ent := zapcore.Entry{Level: level, Time: at, Message: "flood"}
+			if ce := core.Check(ent, nil); ce != nil {
+				ce.Write()
+			}
+		}
+	}
+
+	write(DebugLevel, 500, start)
+	write(InfoLevel, 5000, start)
+	write(ErrorLevel, 5000, start)
+	assert.Equal(t, 100, recorded.FilterLevelExact(DebugLevel).Len())
+	assert.Equal(t, 1000, recorded.FilterLevelExact(InfoLevel).Len())
+	assert.Equal(t, 5000, recorded.FilterLevelExact(ErrorLevel).Len())
+
+	// Half a second refills half of the buckets
+	write(DebugLevel, 500, start.Add(500*time.Millisecond))
+	assert.Equal(t, 150, recorded.FilterLevelExact(DebugLevel).Len())
+
+	logger := &Manager{opt: opt}
+	assert.Equal(t, map[zapcore.Level]uint64{DebugLevel: 850, InfoLevel: 4000}, logger.RateLimitDropped())
+}
@@ -0,0 +1,100 @@
+package logger
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// reconfigureCloseDelay is how long the outputs replaced by Reconfigure stay open for the log calls in flight
+var reconfigureCloseDelay = time.Second
+
+// Reconfigure rebuilds the manager's core from the given options, as New would
+//
+// The options replace the current configuration entirely, unset options take their
+// defaults. Loggers derived from the manager through With and Named switch to the new
+// core as well, and concurrent log calls see either the old or the new core. The outputs
+// of the previous configuration, such as the rotation hook and its log files, are closed
+// one second later, so a log call that picked the old core just before the swap can still
+// write to them; Close closes them right away.
+//
+// With WithStartupSelfTest, the probe entry is written to the new outputs before the swap
+// and a failing probe keeps the current configuration. This is deliberate: the new options
+// may open outputs that were never tested, such as another log path.
+//
+// The level is updated on the manager's atomic level. Options applied by zap when the
+// manager was created keep their original values: the caller skip, the base context, the
+// stack trace level, the panic hook, the fatal behavior and the build information.
+//
+// Parameters:
+//   - opts: A variadic list of Option functions to configure the logger
+//
+// Returns:
+//   - error: An error if the new core cannot be created, the current configuration is kept
+func (m *Manager) Reconfigure(opts ...Option) error {
+	if m.opt == nil || m.opt.root == nil {
+		return errors.New("only managers created by New can be reconfigured")
+	}
+
+	current := m.lockOptions()
+	defer current.rebuild.Unlock()
+
+	next := newOptions(opts...)
+	core, err := newSelfTestedCore(next, m.level)
+	if err != nil {
+		return errors.Join(err, next.close())
+	}
+
+	// Log calls in flight may still write to the previous outputs, so they are closed later,
+	// or by Close if it comes first
+	var once sync.Once
+	var closeErr error
+	closePrevious := func() error {
+		once.Do(func() { closeErr = current.close() })
+		return closeErr
+	}
+	next.closers = append(next.closers, closePrevious)
+
+	next.root = current.root
+	m.level.SetLevel(next.level)
+	current.root.Swap(core)
+	current.replaced.Store(next)
+	time.AfterFunc(reconfigureCloseDelay, func() { _ = closePrevious() })
+
+	return nil
+}
+
+// options returns the options currently in effect, following the replacements made by Reconfigure
+func (m *Manager) options() *option {
+	opt := m.opt
+	if opt == nil {
+		return nil
+	}
+
+	for next := opt.replaced.Load(); next != nil; next = opt.replaced.Load() {
+		opt = next
+	}
+
+	return opt
+}
+
+// lockOptions locks the rebuild mutex of the options currently in effect and returns them
+func (m *Manager) lockOptions() *option {
+	for opt := m.options(); ; opt = m.options() {
+		opt.rebuild.Lock()
+		if opt.replaced.Load() == nil {
+			return opt
+		}
+		opt.rebuild.Unlock()
+	}
+}
+
+// close releases the resources opened for the options
+func (o *option) close() error {
+	var err error
+	for _, closer := range o.closers {
+		err = errors.Join(err, closer())
+	}
+
+	return err
+}
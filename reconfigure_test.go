@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestManager_Reconfigure(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.log")
+	second := filepath.Join(dir, "second.log")
+
+	logger, err := New(WithPlainFile(first))
+	require.NoError(t, err)
+	defer logger.Close()
+
+	ctx := context.Background()
+	derived := logger.With(ctx, zap.String("component", "billing"))
+
+	logger.Info(ctx, "before")
+	require.NoError(t, logger.Reconfigure(WithPlainFile(second), WithLevel("debug")))
+	logger.Log(ctx, DebugLevel, "after")
+	derived.Info("derived after")
+
+	assert.Equal(t, "debug", logger.Config().Level)
+
+	assert.Error(t, logger.Reconfigure(WithDriver("unknown")))
+	logger.Info(ctx, "kept")
+
+	require.NoError(t, logger.Sync())
+	content, err := os.ReadFile(first)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 1)
+	assert.Contains(t, lines[0], `"M":"before"`)
+
+	content, err = os.ReadFile(second)
+	require.NoError(t, err)
+	lines = strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], `"M":"after"`)
+	assert.Contains(t, lines[1], `"M":"derived after"`)
+	assert.Contains(t, lines[1], `"component":"billing"`)
+	assert.Contains(t, lines[2], `"M":"kept"`)
+}
+
+func TestManager_Reconfigure_CloseDelay(t *testing.T) {
+	original := reconfigureCloseDelay
+	reconfigureCloseDelay = 50 * time.Millisecond
+	defer func() { reconfigureCloseDelay = original }()
+
+	dir := t.TempDir()
+	logger, err := New(WithPlainFile(filepath.Join(dir, "first.log")))
+	require.NoError(t, err)
+	defer logger.Close()
+
+	previous := logger.opt.reopenFile
+	require.NoError(t, logger.Reconfigure(WithPlainFile(filepath.Join(dir, "second.log"))))
+
+	_, err = previous.Write([]byte("in flight\n"))
+	assert.NoError(t, err, "the previous output stays open for the log calls in flight")
+
+	assert.Eventually(t, func() bool {
+		_, err := previous.Write([]byte("x"))
+		return err != nil
+	}, time.Second, 10*time.Millisecond, "the previous output is closed after the delay")
+}
+
+func TestManager_Reconfigure_CloseBeforeDelay(t *testing.T) {
+	dir := t.TempDir()
+	logger, err := New(WithPlainFile(filepath.Join(dir, "first.log")))
+	require.NoError(t, err)
+
+	previous := logger.opt.reopenFile
+	require.NoError(t, logger.Reconfigure(WithPlainFile(filepath.Join(dir, "second.log"))))
+	require.NoError(t, logger.Close())
+
+	_, err = previous.Write([]byte("x"))
+	assert.Error(t, err, "Close closes the previous outputs right away")
+}
+
+func TestManager_Reconfigure_FromStdout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	logger, err := New(WithLevel("error"))
+	require.NoError(t, err)
+	defer logger.Close()
+
+	require.NoError(t, logger.Reconfigure(WithPlainFile(path)))
+	logger.Info(context.Background(), "to file")
+	assert.Equal(t, "file", logger.Config().Driver)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"M":"to file"`)
+}
+
+func TestManager_Reconfigure_NotFromNew(t *testing.T) {
+	logger := &Manager{Zap: zap.NewNop()}
+	assert.Error(t, logger.Reconfigure())
+}
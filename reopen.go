@@ -0,0 +1,185 @@
+package logger
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// reopenFile is a WriteSyncer writing to a single file that can be reopened by path
+//
+// External tools such as logrotate rename or truncate the file; reopening makes the
+// process write to the file found at the path again.
+type reopenFile struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+// WithPlainFile writes every entry to a single file, bypassing the built-in rotation
+//
+// This selects the "file" driver. The file is never rotated by the logger, which leaves
+// rotation to an external tool such as logrotate; combine with WithReopenOnSignal when that
+// tool renames the file. Rotation, cleanup and split options have no effect in this mode.
+//
+// Parameters:
+//   - path: The path of the log file
+//
+// Returns:
+//   - Option: A function that sets the plain file in the option struct
+func WithPlainFile(path string) Option {
+	return func(o *option) {
+		o.driver = "file"
+		o.plainFile = path
+	}
+}
+
+// WithReopenOnSignal reopens the plain log file when the process receives SIGHUP
+//
+// Only the file set with WithPlainFile is reopened; rotated files are left alone.
+//
+// Returns:
+//   - Option: A function that enables reopening on SIGHUP in the option struct
+func WithReopenOnSignal() Option {
+	return func(o *option) {
+		o.reopenOnSignal = true
+	}
+}
+
+// newReopenFile opens the file at path for appending
+//
+// Parameters:
+//   - path: The path of the log file
+//
+// Returns:
+//   - *reopenFile: A WriteSyncer writing to the file
+//   - error: An error if the file cannot be opened
+func newReopenFile(path string) (*reopenFile, error) {
+	f := &reopenFile{path: path}
+	if err := f.Reopen(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// newPlainFileSyncer creates the WriteSyncer of the plain file, reopening it on SIGHUP if enabled
+//
+// Parameters:
+//   - opt: The option struct containing configuration
+//
+// Returns:
+//   - zapcore.WriteSyncer: A syncer writing to the plain file
+//   - error: An error if the file cannot be opened
+func newPlainFileSyncer(opt *option) (zapcore.WriteSyncer, error) {
+	if syncer, ok := opt.syncers[opt.plainFile]; ok {
+		return syncer, nil
+	}
+
+	f, err := newReopenFile(opt.plainFile)
+	if err != nil {
+		return nil, err
+	}
+	opt.reopenFile = f
+	opt.closers = append(opt.closers, f.Close)
+
+	if opt.reopenOnSignal {
+		signals := make(chan os.Signal, 1)
+		done := make(chan struct{})
+		signal.Notify(signals, syscall.SIGHUP)
+
+		go func() {
+			for {
+				select {
+				case <-signals:
+					if err := f.Reopen(); err != nil {
+						_, _ = os.Stderr.WriteString("failed to reopen log file: " + err.Error() + "\n")
+					}
+				case <-done:
+					return
+				}
+			}
+		}()
+
+		var once sync.Once
+		opt.closers = append(opt.closers, func() error {
+			once.Do(func() {
+				signal.Stop(signals)
+				close(done)
+			})
+			return nil
+		})
+	}
+
+	syncer, err := encryptFile(opt, f)
+	if err != nil {
+		return nil, err
+	}
+
+	syncer = wrapSyncer(opt, withFileFallback(opt, syncer))
+	opt.cacheSyncer(opt.plainFile, syncer)
+
+	return syncer, nil
+}
+
+// Write writes to the current file
+func (f *reopenFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.file.Write(p)
+}
+
+// Sync flushes the current file
+func (f *reopenFile) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.file.Sync()
+}
+
+// Reopen opens the file found at the path and closes the previous one
+func (f *reopenFile) Reopen() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	previous := f.file
+	f.file = file
+	f.mu.Unlock()
+
+	if previous != nil {
+		return previous.Close()
+	}
+
+	return nil
+}
+
+// Close closes the current file
+func (f *reopenFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.file.Close()
+}
+
+// Reopen reopens the plain log file set with WithPlainFile
+//
+// This is what WithReopenOnSignal does on SIGHUP, for callers handling rotation themselves.
+// It does nothing when the manager does not write to a plain file.
+//
+// Returns:
+//   - error: An error if the file cannot be reopened
+func (m *Manager) Reopen() error {
+	opt := m.options()
+	if opt == nil || opt.reopenFile == nil {
+		return nil
+	}
+
+	return opt.reopenFile.Reopen()
+}
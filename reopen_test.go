@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPlainFile_Reopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	rotated := filepath.Join(dir, "app.log.1")
+
+	logger, err := New(WithPlainFile(path))
+	require.NoError(t, err)
+
+	logger.Info(context.Background(), "before rotation")
+	require.NoError(t, os.Rename(path, rotated))
+	logger.Info(context.Background(), "after rename")
+
+	require.NoError(t, logger.Reopen())
+	logger.Info(context.Background(), "after reopen")
+	require.NoError(t, logger.Close())
+
+	old, err := os.ReadFile(rotated)
+	require.NoError(t, err)
+	assert.Contains(t, string(old), "before rotation")
+	assert.Contains(t, string(old), "after rename")
+
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(current), "after reopen")
+	assert.NotContains(t, string(current), "after rename")
+}
+
+func TestWithReopenOnSignal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("SIGHUP cannot be sent on windows")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	logger, err := New(WithPlainFile(path), WithReopenOnSignal())
+	require.NoError(t, err)
+	defer logger.Close()
+
+	require.NoError(t, os.Rename(path, filepath.Join(dir, "app.log.1")))
+
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, process.Signal(syscall.SIGHUP))
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	logger.Info(context.Background(), "after signal")
+	require.NoError(t, logger.Sync())
+
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(current), "after signal")
+}
+
+func TestWithReopenOnSignal_CloseTwice(t *testing.T) {
+	logger, err := New(WithPlainFile(filepath.Join(t.TempDir(), "app.log")), WithReopenOnSignal())
+	require.NoError(t, err)
+
+	assert.NoError(t, logger.Close())
+	assert.NotPanics(t, func() { _ = logger.Close() })
+}
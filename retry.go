@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Retry constructs the fields describing an attempt of a retry loop
+//
+// The fields are "attempt", "max_attempts" and, when err is not nil, "error". Use
+// RetryLevel or LogRetry to log intermediate and final failures at consistent levels.
+//
+// Parameters:
+//   - attempt: The number of the attempt, starting at 1
+//   - maxAttempts: The maximum number of attempts
+//   - err: The error of the attempt, may be nil
+//
+// Returns:
+//   - []zap.Field: The attempt, max_attempts and error fields
+func Retry(attempt, maxAttempts int, err error) []zap.Field {
+	fields := []zap.Field{
+		zap.Int("attempt", attempt),
+		zap.Int("max_attempts", maxAttempts),
+	}
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+
+	return fields
+}
+
+// RetryLevel returns the level of a failed attempt of a retry loop
+//
+// Parameters:
+//   - attempt: The number of the attempt, starting at 1
+//   - maxAttempts: The maximum number of attempts
+//
+// Returns:
+//   - zapcore.Level: WarnLevel while attempts remain, ErrorLevel for the final attempt
+func RetryLevel(attempt, maxAttempts int) zapcore.Level {
+	if attempt < maxAttempts {
+		return WarnLevel
+	}
+
+	return ErrorLevel
+}
+
+// LogRetry logs a failed attempt of a retry loop with the Retry fields, at RetryLevel
+//
+// Parameters:
+//   - ctx: The context.Context for this log entry
+//   - msg: The message to log
+//   - attempt: The number of the attempt, starting at 1
+//   - maxAttempts: The maximum number of attempts
+//   - err: The error of the attempt
+//   - fields: Optional fields to add to the log entry
+func (m *Manager) LogRetry(ctx context.Context, msg string, attempt, maxAttempts int, err error, fields ...zap.Field) {
+	fields = append(Retry(attempt, maxAttempts, err), fields...)
+
+	logger := m.getLoggerWithTraceID(ctx, fields...)
+	logger.Log(RetryLevel(attempt, maxAttempts), msg, fields...)
+}
@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRetry(t *testing.T) {
+	errTimeout := errors.New("timeout")
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range Retry(2, 3, errTimeout) {
+		f.AddTo(enc)
+	}
+	assert.Equal(t, map[string]interface{}{"attempt": int64(2), "max_attempts": int64(3), "error": "timeout"}, enc.Fields)
+
+	assert.Len(t, Retry(1, 3, nil), 2)
+}
+
+func TestRetryLevel(t *testing.T) {
+	assert.Equal(t, WarnLevel, RetryLevel(1, 3))
+	assert.Equal(t, WarnLevel, RetryLevel(2, 3))
+	assert.Equal(t, ErrorLevel, RetryLevel(3, 3))
+	assert.Equal(t, ErrorLevel, RetryLevel(4, 3))
+}
+
+func TestManager_LogRetry(t *testing.T) {
+	core, recorded := observer.New(InfoLevel)
+	logger := &Manager{Zap: zap.New(core)}
+	errTimeout := errors.New("timeout")
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		logger.LogRetry(context.Background(), "fetch failed", attempt, 3, errTimeout, zap.String("url", "/a"))
+	}
+
+	entries := recorded.All()
+	require.Len(t, entries, 3)
+	assert.Equal(t, WarnLevel, entries[0].Level)
+	assert.Equal(t, WarnLevel, entries[1].Level)
+	assert.Equal(t, ErrorLevel, entries[2].Level)
+	assert.Equal(t, map[string]interface{}{"attempt": int64(3), "max_attempts": int64(3), "error": "timeout", "url": "/a"}, entries[2].ContextMap())
+}
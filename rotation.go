@@ -0,0 +1,170 @@
+package logger
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/file-rotatelogs"
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	// deferredClock is a rotatelogs clock that holds back time-based rotations
+	//
+	// rotatelogs names files after the clock's current time, so keeping the clock at the
+	// start of the current file prevents a rotation. The clock only moves forward once a
+	// rotation boundary has passed and the file has reached the minimum size or the maximum age.
+	deferredClock struct {
+		mu           sync.Mutex
+		now          func() time.Time
+		rotationTime time.Duration
+		minSize      int64         // Minimum size of the current file before it rotates
+		maxAge       time.Duration // Age after which the current file rotates regardless of size
+		started      time.Time     // Creation time of the current file
+		size         int64         // Bytes written to the current file
+	}
+
+	// countingSyncer counts the bytes written through it on the clock
+	countingSyncer struct {
+		zapcore.WriteSyncer
+		clock *deferredClock
+	}
+
+	// funcClock is a rotatelogs clock reading the time from a function
+	funcClock func() time.Time
+)
+
+// WithRotationUTC rotates the log files at UTC period boundaries and names them after the UTC date
+//
+// By default files rotate at local midnight and their names hold the local date, which is
+// confusing when correlating services running in several time zones. The timestamps of
+// the entries are not affected, see WithTimezone.
+// Only used when driver is "file".
+//
+// Returns:
+//   - Option: A function that enables UTC rotation in the option struct
+func WithRotationUTC() Option {
+	return func(o *option) {
+		o.rotationUTC = true
+	}
+}
+
+// newRotationClock creates the clock deciding the rotations and the file names of the file driver
+//
+// Parameters:
+//   - opt: The option struct containing configuration
+//   - now: The source of the current time
+//
+// Returns:
+//   - rotatelogs.Clock: A *deferredClock when a minimum rotation size is set, a funcClock otherwise
+func newRotationClock(opt *option, now func() time.Time) rotatelogs.Clock {
+	now = rotationNow(opt, now)
+	if opt.minRotationSize > 0 {
+		return newDeferredClock(opt.rotationTime, opt.minRotationSize, opt.maxAge, now)
+	}
+
+	return funcClock(now)
+}
+
+// rotationNow returns the source of the current time used for rotations, in UTC if configured
+func rotationNow(opt *option, now func() time.Time) func() time.Time {
+	if !opt.rotationUTC {
+		return now
+	}
+
+	return func() time.Time {
+		return now().UTC()
+	}
+}
+
+// Now implements rotatelogs.Clock
+func (f funcClock) Now() time.Time {
+	return f()
+}
+
+// WithMinRotationSize defers time-based rotation until the file reaches a minimum size
+//
+// At each rotation time boundary the file is only rotated if it holds at least the given
+// number of bytes, which avoids many nearly empty files under low traffic. A file is still
+// rotated once it is older than maxAge, so retention keeps working. The deferred file keeps
+// the name of the period it was created in.
+// Only used when driver is "file".
+//
+// Parameters:
+//   - bytes: The minimum file size before a rotation
+//
+// Returns:
+//   - Option: A function that sets the minimum rotation size in the option struct
+func WithMinRotationSize(bytes int) Option {
+	return func(o *option) {
+		o.minRotationSize = int64(bytes)
+	}
+}
+
+// newDeferredClock creates a clock deferring rotations
+//
+// Parameters:
+//   - rotationTime: The time between rotations
+//   - minSize: The minimum file size before a rotation
+//   - maxAge: The age after which a file rotates regardless of its size
+//   - now: The source of the current time
+//
+// Returns:
+//   - *deferredClock: A new clock starting at the current time
+func newDeferredClock(rotationTime time.Duration, minSize int64, maxAge time.Duration, now func() time.Time) *deferredClock {
+	return &deferredClock{
+		now:          now,
+		rotationTime: rotationTime,
+		minSize:      minSize,
+		maxAge:       maxAge,
+		started:      now(),
+	}
+}
+
+// Now implements rotatelogs.Clock
+func (c *deferredClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if !periodStart(now, c.rotationTime).After(periodStart(c.started, c.rotationTime)) {
+		return now
+	}
+
+	if c.size >= c.minSize || (c.maxAge > 0 && now.Sub(c.started) >= c.maxAge) {
+		c.started = now
+		c.size = 0
+		return now
+	}
+
+	// Report the creation time so rotatelogs keeps writing to the current file
+	return c.started
+}
+
+// countWrites wraps a syncer to count the bytes written to the current file
+func (c *deferredClock) countWrites(syncer zapcore.WriteSyncer) zapcore.WriteSyncer {
+	return &countingSyncer{WriteSyncer: syncer, clock: c}
+}
+
+// Write implements zapcore.WriteSyncer
+func (s *countingSyncer) Write(p []byte) (int, error) {
+	n, err := s.WriteSyncer.Write(p)
+
+	s.clock.mu.Lock()
+	s.clock.size += int64(n)
+	s.clock.mu.Unlock()
+
+	return n, err
+}
+
+// periodStart returns the start of the rotation period holding t
+//
+// It mirrors rotatelogs' truncation, which truncates the local wall-clock time.
+func periodStart(t time.Time, rotationTime time.Duration) time.Time {
+	if rotationTime <= 0 {
+		return t
+	}
+
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	return wall.Truncate(rotationTime)
+}
@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/file-rotatelogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestDeferredClock(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 1, 2, 10, 30, 0, 0, time.Local)
+	clock := newDeferredClock(time.Hour, 20, 24*time.Hour, func() time.Time { return now })
+
+	hook, err := rotatelogs.New(filepath.Join(dir, "%Y%m%d%H.log"), rotatelogs.WithRotationTime(time.Hour), rotatelogs.WithClock(clock))
+	require.NoError(t, err)
+	defer hook.Close()
+	syncer := clock.countWrites(zapcore.AddSync(hook))
+
+	write := func(entry string) {
+		_, err := syncer.Write([]byte(entry))
+		require.NoError(t, err)
+	}
+	files := func() []string {
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	write("small\n")
+	now = now.Add(2 * time.Hour)
+	write("small\n")
+	assert.Equal(t, []string{"2024010210.log"}, files(), "rotation deferred below the minimum size")
+
+	write("this pushes the file over the minimum\n")
+	now = now.Add(time.Hour)
+	write("small\n")
+	assert.Equal(t, []string{"2024010210.log", "2024010213.log"}, files(), "rotation once the minimum size is reached")
+
+	now = now.Add(25 * time.Hour)
+	write("small\n")
+	assert.Equal(t, []string{"2024010210.log", "2024010213.log", "2024010314.log"}, files(), "rotation once the maximum age is reached")
+}
+
+func TestWithRotationUTC(t *testing.T) {
+	// 05:00 on January 2nd at UTC+10 is still January 1st in UTC
+	now := time.Date(2024, 1, 2, 5, 0, 0, 0, time.FixedZone("UTC+10", 10*60*60))
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"Local", nil, "2024-01-02.log"},
+		{"UTC", []Option{WithRotationUTC()}, "2024-01-01.log"},
+		{"UTC with minimum size", []Option{WithRotationUTC(), WithMinRotationSize(1)}, "2024-01-01.log"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			opt := newOptions(tt.opts...)
+			clock := newRotationClock(opt, func() time.Time { return now })
+
+			hook, err := rotatelogs.New(filepath.Join(dir, "%Y-%m-%d.log"), rotatelogs.WithRotationTime(opt.rotationTime), rotatelogs.WithClock(clock))
+			require.NoError(t, err)
+			defer hook.Close()
+
+			_, err = hook.Write([]byte("entry\n"))
+			require.NoError(t, err)
+			assert.FileExists(t, filepath.Join(dir, tt.want))
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package logger
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/file-rotatelogs"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// RouteMaxOpenFiles is the number of files WithRouteByField keeps open, the least recently used is closed first
+	RouteMaxOpenFiles = 64
+
+	// routeDefaultName is the file name of the entries without the routing field, '_' is always escaped in values
+	routeDefaultName = "_default"
+)
+
+type (
+	// routeCore is a zapcore.Core writing each entry to the file named after a field's value
+	routeCore struct {
+		zapcore.LevelEnabler
+		levelChecked
+		key   string
+		value string // Value of the key added through With, empty when none
+		enc   zapcore.Encoder
+		files *routeFiles
+	}
+
+	// routeFiles holds the open files of WithRouteByField, closing the least recently used
+	routeFiles struct {
+		mu      sync.Mutex
+		opt     *option
+		dir     string
+		maxOpen int
+		lru     *list.List               // Open files, most recently used first
+		open    map[string]*list.Element // Open files by name
+	}
+
+	// routeFile is an open file of WithRouteByField
+	routeFile struct {
+		name   string
+		hook   *rotatelogs.RotateLogs
+		syncer zapcore.WriteSyncer
+	}
+)
+
+// WithRouteByField additionally writes each entry to a file named after the value of a field
+//
+// For multi-tenant systems, WithRouteByField("tenant", "/var/log/myapp/tenants") writes the
+// entries of each tenant to <dir>/<tenant>.log, with the same rotation and retention as the
+// file driver: <tenant>.log is a symlink to the current rotated file beside it, e.g.
+// <dir>/<tenant>.<YYYY-MM-DD>.log. The field may be passed to the log call or added through
+// With; entries without it, or with an empty value, go to _default.log. Lowercase letters,
+// digits and '-' are kept in the file name, every other byte is escaped as '=' and its hex
+// code, e.g. "Acme EU" becomes =41cme=20=45=55.log, so distinct values never share a file,
+// even on case-insensitive file systems, and no value maps to _default.log. At most
+// RouteMaxOpenFiles files are kept open, the least recently used one is closed when another
+// is needed. The entries are still written to the configured driver, with the configured
+// encoding.
+//
+// Parameters:
+//   - key: The key of the field selecting the file
+//   - dir: The directory holding the routed files
+//
+// Returns:
+//   - Option: A function that sets the routing field in the option struct
+func WithRouteByField(key, dir string) Option {
+	return func(o *option) {
+		o.routeKey = key
+		o.routeDir = dir
+	}
+}
+
+// newRouteCore creates the core writing the entries to the files of their field value
+//
+// Parameters:
+//   - opt: The option struct containing configuration
+//   - encoder: The zapcore.Encoder to use
+//   - level: The level enabler of the core
+//
+// Returns:
+//   - zapcore.Core: A core writing each entry to the file of its field value
+func newRouteCore(opt *option, encoder zapcore.Encoder, level zapcore.LevelEnabler) zapcore.Core {
+	if opt.routeFiles == nil {
+		opt.routeFiles = newRouteFiles(opt, opt.routeDir, RouteMaxOpenFiles)
+		opt.closers = append(opt.closers, opt.routeFiles.Close)
+	}
+
+	return &routeCore{LevelEnabler: level, key: opt.routeKey, enc: encoder.Clone(), files: opt.routeFiles}
+}
+
+// With adds structured context to the core, keeping the routing field's value
+func (c *routeCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.enc = c.enc.Clone()
+	for _, f := range fields {
+		f.AddTo(clone.enc)
+		if f.Key == c.key {
+			clone.value = routeValue(f)
+		}
+	}
+
+	return &clone
+}
+
+// Check adds the core if the entry's level is enabled
+func (c *routeCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write encodes the entry and writes it to the file of its routing field's value
+func (c *routeCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	value := c.value
+	for _, f := range fields {
+		if f.Key == c.key {
+			value = routeValue(f)
+		}
+	}
+
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	defer buf.Free()
+
+	return c.files.write(value, buf.Bytes())
+}
+
+// Sync flushes the open files
+func (c *routeCore) Sync() error {
+	return c.files.Sync()
+}
+
+// routeValue returns the value of a field as a string
+func routeValue(f zapcore.Field) string {
+	if f.Type == zapcore.StringType {
+		return f.String
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+
+	return fmt.Sprint(enc.Fields[f.Key])
+}
+
+// routeFileName turns a field value into a safe file name, distinct for every value
+//
+// '=' is used instead of '%', which would start a strftime verb in the rotation pattern. '.'
+// is escaped as well, so the rotation glob of a name, <name>.*.log, never matches the files
+// of another name.
+func routeFileName(value string) string {
+	if value == "" {
+		return routeDefaultName
+	}
+
+	var name strings.Builder
+	for i := 0; i < len(value); i++ {
+		b := value[i]
+		if b == '-' || b >= 'a' && b <= 'z' || b >= '0' && b <= '9' {
+			name.WriteByte(b)
+			continue
+		}
+		fmt.Fprintf(&name, "=%02X", b)
+	}
+
+	return name.String()
+}
+
+// newRouteFiles creates an empty set of routed files
+//
+// Parameters:
+//   - opt: The option struct holding the rotation settings
+//   - dir: The directory holding the routed files
+//   - maxOpen: The maximum number of open files
+//
+// Returns:
+//   - *routeFiles: A new set of routed files
+func newRouteFiles(opt *option, dir string, maxOpen int) *routeFiles {
+	return &routeFiles{opt: opt, dir: dir, maxOpen: maxOpen, lru: list.New(), open: make(map[string]*list.Element)}
+}
+
+// write writes an encoded entry to the file of a field value, opening it if needed
+func (f *routeFiles) write(value string, p []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := f.get(routeFileName(value))
+	if err != nil {
+		return err
+	}
+
+	_, err = file.syncer.Write(p)
+
+	return err
+}
+
+// get returns the open file of a name, opening it and closing the least recently used if needed
+func (f *routeFiles) get(name string) (*routeFile, error) {
+	if elem, ok := f.open[name]; ok {
+		f.lru.MoveToFront(elem)
+		return elem.Value.(*routeFile), nil
+	}
+
+	hook, err := rotatelogs.New(
+		filepath.Join(f.dir, name+".%Y-%m-%d.log"),
+		rotatelogs.WithLinkName(filepath.Join(f.dir, name+".log")),
+		rotatelogs.WithMaxAge(f.opt.maxAge),
+		rotatelogs.WithRotationTime(f.opt.rotationTime),
+		rotatelogs.WithClock(funcClock(rotationNow(f.opt, time.Now))),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.lru.Len() >= f.maxOpen {
+		oldest := f.lru.Remove(f.lru.Back()).(*routeFile)
+		delete(f.open, oldest.name)
+		_ = oldest.hook.Close()
+	}
+
+	syncer, err := encryptFile(f.opt, zapcore.AddSync(hook))
+	if err != nil {
+		_ = hook.Close()
+		return nil, err
+	}
+
+	file := &routeFile{name: name, hook: hook, syncer: wrapSyncer(f.opt, syncer)}
+	f.open[name] = f.lru.PushFront(file)
+
+	return file, nil
+}
+
+// Sync flushes the open files
+func (f *routeFiles) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var err error
+	for elem := f.lru.Front(); elem != nil; elem = elem.Next() {
+		err = errors.Join(err, elem.Value.(*routeFile).syncer.Sync())
+	}
+
+	return err
+}
+
+// Close closes the open files
+func (f *routeFiles) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var err error
+	for elem := f.lru.Front(); elem != nil; elem = elem.Next() {
+		err = errors.Join(err, elem.Value.(*routeFile).hook.Close())
+	}
+	f.lru.Init()
+	f.open = make(map[string]*list.Element)
+
+	return err
+}
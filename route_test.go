@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestWithRouteByField(t *testing.T) {
+	dir := t.TempDir()
+	logger, err := New(WithEncoding("json"), WithRouteByField("tenant", dir))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	logger.Info(ctx, "order created", zap.String("tenant", "acme"))
+	logger.Info(ctx, "order created", zap.String("tenant", "globex"))
+	logger.With(ctx, zap.String("tenant", "acme")).Info("order paid")
+	logger.Info(ctx, "healthcheck")
+	logger.Info(ctx, "escape attempt", zap.String("tenant", "../../etc/passwd"))
+	logger.Info(ctx, "named default", zap.String("tenant", "default"))
+	require.NoError(t, logger.Close())
+
+	lines := func(name string) []string {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		return strings.Split(strings.TrimSpace(string(content)), "\n")
+	}
+
+	acme := lines("acme.log")
+	require.Len(t, acme, 2)
+	assert.Contains(t, acme[0], `"M":"order created"`)
+	assert.Contains(t, acme[1], `"M":"order paid"`)
+
+	globex := lines("globex.log")
+	require.Len(t, globex, 1)
+	assert.Contains(t, globex[0], `"tenant":"globex"`)
+
+	assert.Equal(t, lines("acme.log"), lines("acme."+time.Now().Format("2006-01-02")+".log"), "the link points to the rotated file")
+
+	require.Len(t, lines("_default.log"), 1)
+	assert.Contains(t, lines("_default.log")[0], "healthcheck")
+	assert.Contains(t, lines("default.log")[0], "named default")
+	assert.Contains(t, lines("=2E=2E=2F=2E=2E=2Fetc=2Fpasswd.log")[0], "escape attempt")
+}
+
+func TestRouteFileName(t *testing.T) {
+	values := []string{"acme/eu", "acme eu", "acme_eu", "acme=5Feu", "Acme", "acme", "default", "_default", ".", "..", "acme.2026-01-02"}
+
+	names := make(map[string]string, len(values))
+	for _, value := range values {
+		name := routeFileName(value)
+		assert.NotContains(t, names, name, "%q and %q share a file", value, names[name])
+		assert.NotEqual(t, routeDefaultName, name, value)
+		assert.NotContains(t, name, ".", value)
+		assert.NotContains(t, name, "/", value)
+		assert.NotContains(t, name, "%", value)
+		names[name] = value
+	}
+
+	assert.Equal(t, "acme=2Feu", routeFileName("acme/eu"))
+	assert.Equal(t, routeDefaultName, routeFileName(""))
+}
+
+func TestRouteFiles_LRU(t *testing.T) {
+	dir := t.TempDir()
+	files := newRouteFiles(&option{rotationTime: 24 * time.Hour, maxAge: 24 * time.Hour}, dir, 2)
+	defer files.Close()
+
+	require.NoError(t, files.write("a", []byte("a1\n")))
+	require.NoError(t, files.write("b", []byte("b1\n")))
+	require.NoError(t, files.write("c", []byte("c1\n")))
+	assert.Len(t, files.open, 2)
+	assert.NotContains(t, files.open, "a", "the least recently used file is closed")
+
+	require.NoError(t, files.write("a", []byte("a2\n")))
+	assert.NotContains(t, files.open, "b")
+
+	content, err := os.ReadFile(filepath.Join(dir, "a.log"))
+	require.NoError(t, err)
+	assert.Equal(t, "a1\na2\n", string(content), "a reopened file is appended to")
+}
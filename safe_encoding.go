@@ -0,0 +1,148 @@
+package logger
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// encodeErrorPlaceholder replaces the value of a field that cannot be encoded
+const encodeErrorPlaceholder = "<encode-error>"
+
+// safeEncodingCore is a zapcore.Core replacing the fields whose encoding panics
+type safeEncodingCore struct {
+	zapcore.Core
+	levelChecked
+	onError   func(err error) // Called with each encoding error, may be nil
+	reporting *atomic.Bool    // Set while onError runs, guards against recursion
+}
+
+// WithSafeEncoding replaces the value of a field whose encoding panics by "<encode-error>"
+//
+// A panic in a user-defined marshaler, e.g. a MarshalJSON or MarshalLogObject method,
+// would otherwise crash the logging goroutine. With this option the entry is written with
+// the faulty fields replaced, and each encoding error is passed to the WithOnError callback.
+// Fields added through With are handled the same way. Entries without faulty fields are
+// encoded once, as usual.
+//
+// Returns:
+//   - Option: A function that enables safe encoding in the option struct
+func WithSafeEncoding() Option {
+	return func(o *option) {
+		o.safeEncoding = true
+	}
+}
+
+// newSafeEncodingCore wraps a core to recover from panics while encoding fields
+//
+// Parameters:
+//   - core: The core to wrap
+//   - onError: The function called with each encoding error, may be nil
+//   - reporting: The flag guarding onError against recursion
+//
+// Returns:
+//   - zapcore.Core: A core replacing the fields that cannot be encoded
+func newSafeEncodingCore(core zapcore.Core, onError func(err error), reporting *atomic.Bool) zapcore.Core {
+	return &safeEncodingCore{Core: core, onError: onError, reporting: reporting}
+}
+
+// With adds structured context to the core, replacing the fields that cannot be encoded
+func (c *safeEncodingCore) With(fields []zapcore.Field) zapcore.Core {
+	core, ok := c.tryWith(fields)
+	if !ok {
+		core = c.Core.With(c.sanitize(fields))
+	}
+
+	return &safeEncodingCore{Core: core, onError: c.onError, reporting: c.reporting}
+}
+
+// Check adds the core if the entry's level is enabled
+func (c *safeEncodingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write writes the entry, writing it again with the faulty fields replaced if encoding panics
+func (c *safeEncodingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if err, ok := c.tryWrite(ent, fields); ok {
+		return err
+	}
+
+	if err, ok := c.tryWrite(ent, c.sanitize(fields)); ok {
+		return err
+	}
+
+	return fmt.Errorf("failed to encode entry %q", ent.Message)
+}
+
+// tryWith adds the fields to the wrapped core, reporting whether it did not panic
+func (c *safeEncodingCore) tryWith(fields []zapcore.Field) (core zapcore.Core, ok bool) {
+	defer func() {
+		if recover() != nil {
+			core, ok = nil, false
+		}
+	}()
+
+	return c.Core.With(fields), true
+}
+
+// tryWrite writes the entry to the wrapped core, reporting whether it did not panic
+//
+// The encoded entry is only written once encoding succeeded, so a panic leaves the outputs untouched.
+func (c *safeEncodingCore) tryWrite(ent zapcore.Entry, fields []zapcore.Field) (err error, ok bool) {
+	defer func() {
+		if recover() != nil {
+			err, ok = nil, false
+		}
+	}()
+
+	return writeEntry(c.Core, ent, fields), true
+}
+
+// sanitize returns the fields with the ones whose encoding panics replaced by a placeholder
+func (c *safeEncodingCore) sanitize(fields []zapcore.Field) []zapcore.Field {
+	sanitized := fields
+	copied := false
+	for i, f := range fields {
+		err := encodeField(f)
+		if err == nil {
+			continue
+		}
+
+		// Copy before replacing, the caller's slice must not be modified
+		if !copied {
+			sanitized = append([]zapcore.Field(nil), fields...)
+			copied = true
+		}
+		sanitized[i] = zap.String(f.Key, encodeErrorPlaceholder)
+		c.report(err)
+	}
+
+	return sanitized
+}
+
+// report passes an encoding error to the callback, unless the callback is already running
+func (c *safeEncodingCore) report(err error) {
+	if c.onError != nil && c.reporting.CompareAndSwap(false, true) {
+		defer c.reporting.Store(false)
+		c.onError(err)
+	}
+}
+
+// encodeField encodes a field on its own, returning an error if encoding panics
+func encodeField(f zapcore.Field) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to encode field %q: %v", f.Key, r)
+		}
+	}()
+
+	f.AddTo(zapcore.NewJSONEncoder(zapcore.EncoderConfig{}))
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	// panickingJSON is a value whose MarshalJSON panics
+	panickingJSON struct{}
+
+	// panickingObject is a value whose MarshalLogObject panics after writing a field
+	panickingObject struct{}
+)
+
+func (panickingJSON) MarshalJSON() ([]byte, error) {
+	panic("marshal failed")
+}
+
+func (panickingObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("partial", "value")
+	panic("marshal failed")
+}
+
+func TestWithSafeEncoding(t *testing.T) {
+	out := &bytes.Buffer{}
+	var errs []error
+	logger, err := New(
+		WithSafeEncoding(),
+		WithOnError(func(err error) { errs = append(errs, err) }),
+		WithSinkEncoded("json", false, out, zapcore.InfoLevel),
+	)
+	require.NoError(t, err)
+	defer logger.Close()
+
+	ctx := context.Background()
+	logger.Info(ctx, "order created", zap.Any("payload", panickingJSON{}), zap.Object("details", panickingObject{}), zap.String("order", "o-1"))
+	logger.With(ctx, zap.Any("request", panickingJSON{})).Info("order paid")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry), "the line is valid JSON")
+	assert.Equal(t, "order created", entry["M"])
+	assert.Equal(t, "<encode-error>", entry["payload"])
+	assert.Equal(t, "<encode-error>", entry["details"])
+	assert.Equal(t, "o-1", entry["order"])
+
+	entry = nil
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry), "the line is valid JSON")
+	assert.Equal(t, "order paid", entry["M"])
+	assert.Equal(t, "<encode-error>", entry["request"])
+
+	require.Len(t, errs, 3)
+	assert.ErrorContains(t, errs[0], `"payload"`)
+	assert.ErrorContains(t, errs[1], `"details"`)
+	assert.ErrorContains(t, errs[2], `"request"`)
+}
+
+func TestWithSafeEncoding_ValidFields(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger, err := New(WithSafeEncoding(), WithSinkEncoded("json", false, out, zapcore.InfoLevel))
+	require.NoError(t, err)
+	defer logger.Close()
+
+	logger.Info(context.Background(), "order created", zap.Any("items", []string{"a", "b"}))
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &entry))
+	assert.Equal(t, []interface{}{"a", "b"}, entry["items"])
+}
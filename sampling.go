@@ -0,0 +1,366 @@
+package logger
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// noSampleKey is the key of the marker field created by NoSample
+const noSampleKey = "logger.no_sample"
+
+type (
+	// callerSampling holds the configuration for caller-keyed sampling
+	callerSampling struct {
+		initial    int           // Number of entries per caller logged in each tick
+		thereafter int           // After initial, every Nth entry per caller is logged
+		tick       time.Duration // Sampling interval
+	}
+
+	// levelSampling holds the configuration for level-restricted sampling
+	levelSampling struct {
+		maxLevel   zapcore.Level // Most severe level that is sampled
+		initial    int           // Number of entries per message logged in each tick
+		thereafter int           // After initial, every Nth entry per message is logged
+		tick       time.Duration // Sampling interval
+	}
+
+	// samplingHook observes the decisions made by the samplers
+	samplingHook func(zapcore.Entry, zapcore.SamplingDecision)
+
+	// callerSamplingCore is a zapcore.Core that samples entries per call site
+	callerSamplingCore struct {
+		zapcore.Core
+		levelChecked
+		config   callerSampling
+		hook     samplingHook // Called with every sampling decision, may be nil
+		exempt   []string     // Message prefixes of the entries never sampled
+		counters *sync.Map    // Sampling counters keyed by level and caller
+	}
+
+	// levelSamplingCore is a zapcore.Core that only samples entries up to a given level
+	levelSamplingCore struct {
+		zapcore.Core               // Core receiving the entries above maxLevel unsampled
+		sampled      zapcore.Core  // Sampling core wrapping the same core
+		maxLevel     zapcore.Level // Most severe level that is sampled
+		exempt       []string      // Message prefixes of the entries never sampled
+	}
+
+	// samplingCounter counts the entries of one key within the current tick
+	samplingCounter struct {
+		resetAt atomic.Int64
+		count   atomic.Uint64
+	}
+)
+
+// WithCallerSampling enables sampling keyed by the entry's caller (file:line)
+//
+// Unlike zap's sampler, which keys by message, each call site is rate-limited independently,
+// so generic messages logged from many places do not starve each other.
+//
+// Parameters:
+//   - initial: The number of entries per caller logged in each tick
+//   - thereafter: After initial, every Nth entry per caller is logged (0 drops all)
+//   - tick: The sampling interval
+//
+// Returns:
+//   - Option: A function that sets the caller sampling in the option struct
+func WithCallerSampling(initial, thereafter int, tick time.Duration) Option {
+	return func(o *option) {
+		o.callerSampling = &callerSampling{
+			initial:    initial,
+			thereafter: thereafter,
+			tick:       tick,
+		}
+	}
+}
+
+// WithLevelSampling enables sampling for entries at or below a given level
+//
+// Entries at or below maxSampledLevel are sampled per message like zap's sampler, while
+// more severe entries are always logged, e.g. sample Debug and Info but keep every Warn and Error.
+//
+// Parameters:
+//   - maxSampledLevel: The most severe level that is sampled
+//   - initial: The number of entries per message logged in each tick
+//   - thereafter: After initial, every Nth entry per message is logged (0 drops all)
+//   - tick: The sampling interval
+//
+// Returns:
+//   - Option: A function that sets the level sampling in the option struct
+func WithLevelSampling(maxSampledLevel zapcore.Level, initial, thereafter int, tick time.Duration) Option {
+	return func(o *option) {
+		o.levelSampling = &levelSampling{
+			maxLevel:   maxSampledLevel,
+			initial:    initial,
+			thereafter: thereafter,
+			tick:       tick,
+		}
+	}
+}
+
+// WithSamplingHook sets a function called with every sampling decision
+//
+// The hook receives zapcore.LogDropped or zapcore.LogSampled for each entry seen by a
+// sampler, which makes it possible to export metrics on how much is being dropped.
+// It runs on the logging goroutine and must be fast.
+//
+// Parameters:
+//   - hook: The function called with each entry and its sampling decision
+//
+// Returns:
+//   - Option: A function that sets the sampling hook in the option struct
+func WithSamplingHook(hook func(zapcore.Entry, zapcore.SamplingDecision)) Option {
+	return func(o *option) {
+		o.samplingHook = hook
+	}
+}
+
+// WithSamplingExempt exempts the entries whose message starts with one of the prefixes from sampling
+//
+// For a fixed set of lifecycle or audit messages this is simpler than marking every call
+// with NoSample: the entries are always written by WithCallerSampling and WithLevelSampling
+// and do not count against the sampling budgets. Other limits such as WithRateLimitByLevel
+// and WithCallerThrottle still apply. The prefixes are matched case-sensitively against the
+// message, before WithMessagePrefix is applied.
+//
+// Parameters:
+//   - prefixes: The message prefixes, e.g. "audit:" or "shutting down"
+//
+// Returns:
+//   - Option: A function that adds the exempt prefixes to the option struct
+func WithSamplingExempt(prefixes ...string) Option {
+	return func(o *option) {
+		o.samplingExempt = append(o.samplingExempt, prefixes...)
+	}
+}
+
+// NoSample constructs a marker field exempting the entry from sampling
+//
+// Critical entries, e.g. "shutting down", are always written by WithCallerSampling and
+// WithLevelSampling when they carry this field, and do not count against the sampling
+// budgets. The field itself is not written. Other limits such as WithRateLimitByLevel and
+// WithCallerThrottle still apply. It is only recognized among the fields of the log call,
+// not among fields added through With.
+//
+// Returns:
+//   - zap.Field: The marker field
+func NoSample() zap.Field {
+	return zap.Field{Key: noSampleKey, Type: zapcore.SkipType}
+}
+
+// InfoImportant logs a message at InfoLevel that is never sampled away
+//
+// It is Info with the NoSample marker, for key lifecycle events such as startup and shutdown.
+//
+// Parameters:
+//   - ctx: The context.Context for this log entry
+//   - msg: The message to log
+//   - fields: Optional fields to add to the log entry
+func (m *Manager) InfoImportant(ctx context.Context, msg string, fields ...zap.Field) {
+	fields = append(fields[:len(fields):len(fields)], NoSample())
+
+	logger := m.getLoggerWithTraceID(ctx, fields...)
+	logger.Info(msg, fields...)
+}
+
+// newCallerSamplingCore wraps a core with caller-keyed sampling
+//
+// Parameters:
+//   - core: The core to wrap
+//   - config: The sampling configuration
+//   - hook: The function called with every sampling decision, may be nil
+//   - exempt: The message prefixes of the entries never sampled
+//
+// Returns:
+//   - zapcore.Core: A core that samples entries per caller
+func newCallerSamplingCore(core zapcore.Core, config callerSampling, hook samplingHook, exempt []string) zapcore.Core {
+	return &callerSamplingCore{
+		Core:     core,
+		config:   config,
+		hook:     hook,
+		exempt:   exempt,
+		counters: &sync.Map{},
+	}
+}
+
+// newLevelSamplingCore wraps a core with sampling restricted to the less severe levels
+//
+// Parameters:
+//   - core: The core to wrap
+//   - config: The sampling configuration
+//   - hook: The function called with every sampling decision, may be nil
+//   - exempt: The message prefixes of the entries never sampled
+//
+// Returns:
+//   - zapcore.Core: A core that samples entries up to config.maxLevel
+func newLevelSamplingCore(core zapcore.Core, config levelSampling, hook samplingHook, exempt []string) zapcore.Core {
+	var opts []zapcore.SamplerOption
+	if hook != nil {
+		opts = append(opts, zapcore.SamplerHook(hook))
+	}
+
+	return &levelSamplingCore{
+		Core:     core,
+		sampled:  zapcore.NewSamplerWithOptions(core, config.tick, config.initial, config.thereafter, opts...),
+		maxLevel: config.maxLevel,
+		exempt:   exempt,
+	}
+}
+
+// With adds structured context to both cores, sharing the sampling counters
+func (c *levelSamplingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelSamplingCore{
+		Core:     c.Core.With(fields),
+		sampled:  c.sampled.With(fields),
+		maxLevel: c.maxLevel,
+		exempt:   c.exempt,
+	}
+}
+
+// Check defers the sampling of sampled levels to Write, where NoSample can be seen
+func (c *levelSamplingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level > c.maxLevel {
+		return c.Core.Check(ent, ce)
+	}
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write routes the entry through the sampler unless it is exempt from sampling
+func (c *levelSamplingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if isSamplingExempt(ent, fields, c.exempt) {
+		return writeEntry(c.Core, ent, fields)
+	}
+
+	// The sampler only decides, the entry is written to the wrapped core like the exempt ones
+	if c.sampled.Check(ent, nil) == nil {
+		return nil
+	}
+
+	return writeEntry(c.Core, ent, fields)
+}
+
+// checkWrite implements checkedWriter, passing the levels above the sampled ones through as Check does
+func (c *levelSamplingCore) checkWrite(ent zapcore.Entry, fields []zapcore.Field) error {
+	if ent.Level > c.maxLevel {
+		return writeEntry(c.Core, ent, fields)
+	}
+	if !c.Enabled(ent.Level) {
+		return nil
+	}
+
+	return c.Write(ent, fields)
+}
+
+// With adds structured context to the core, sharing the sampling counters
+func (c *callerSamplingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &callerSamplingCore{
+		Core:     c.Core.With(fields),
+		config:   c.config,
+		hook:     c.hook,
+		exempt:   c.exempt,
+		counters: c.counters,
+	}
+}
+
+// Check defers the sampling decision to Write, where the caller is known
+func (c *callerSamplingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write logs the entry if it is exempt from sampling or its caller has not exceeded the sampling budget
+func (c *callerSamplingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if isSamplingExempt(ent, fields, c.exempt) {
+		return writeEntry(c.Core, ent, fields)
+	}
+
+	if !c.sample(ent) {
+		c.hook.call(ent, zapcore.LogDropped)
+		return nil
+	}
+
+	c.hook.call(ent, zapcore.LogSampled)
+	return writeEntry(c.Core, ent, fields)
+}
+
+// sample reports whether the entry should be logged
+func (c *callerSamplingCore) sample(ent zapcore.Entry) bool {
+	key := ent.Level.String() + "@" + callerKey(ent)
+	v, _ := c.counters.LoadOrStore(key, &samplingCounter{})
+
+	n := v.(*samplingCounter).incCheckReset(ent.Time, c.config.tick)
+	first := uint64(c.config.initial)
+	if n <= first {
+		return true
+	}
+
+	return c.config.thereafter > 0 && (n-first)%uint64(c.config.thereafter) == 0
+}
+
+// callerKey returns the file:line of the entry's caller, or its message if the caller is unknown
+func callerKey(ent zapcore.Entry) string {
+	if !ent.Caller.Defined {
+		return ent.Message
+	}
+
+	return ent.Caller.String()
+}
+
+// incCheckReset increments the counter, resetting it first if the tick has elapsed
+func (s *samplingCounter) incCheckReset(t time.Time, tick time.Duration) uint64 {
+	now := t.UnixNano()
+	resetAt := s.resetAt.Load()
+	if resetAt > now {
+		return s.count.Add(1)
+	}
+
+	s.count.Store(1)
+	if !s.resetAt.CompareAndSwap(resetAt, now+tick.Nanoseconds()) {
+		// Another goroutine reset the counter concurrently, count this entry on top of it
+		return s.count.Add(1)
+	}
+
+	return 1
+}
+
+// isSamplingExempt reports whether the entry is marked with NoSample or its message has an exempt prefix
+func isSamplingExempt(ent zapcore.Entry, fields []zapcore.Field, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(ent.Message, prefix) {
+			return true
+		}
+	}
+
+	return hasNoSample(fields)
+}
+
+// hasNoSample reports whether the fields hold the marker created by NoSample
+func hasNoSample(fields []zapcore.Field) bool {
+	for _, f := range fields {
+		if f.Type == zapcore.SkipType && f.Key == noSampleKey {
+			return true
+		}
+	}
+
+	return false
+}
+
+// call invokes the hook if it is set
+func (h samplingHook) call(ent zapcore.Entry, decision zapcore.SamplingDecision) {
+	if h != nil {
+		h(ent, decision)
+	}
+}
@@ -0,0 +1,157 @@
+package logger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestCallerSamplingCore(t *testing.T) {
+	obs, recorded := observer.New(zapcore.DebugLevel)
+	core := newCallerSamplingCore(obs, callerSampling{initial: 2, thereafter: 3, tick: time.Minute}, nil, nil)
+
+	callerA := zapcore.NewEntryCaller(0, "service/a.go", 10, true)
+	callerB := zapcore.NewEntryCaller(0, "service/b.go", 20, true)
+
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		for _, caller := range []zapcore.EntryCaller{callerA, callerB} {
+			ent := zapcore.Entry{Level: zapcore.InfoLevel, Time: now, Message: "request failed", Caller: caller}
+			if ce := core.Check(ent, nil); ce != nil {
+				ce.Write()
+			}
+		}
+	}
+
+	// Each caller logs its first 2 entries, then entries 5 and 8
+	perCaller := map[string]int{}
+	for _, entry := range recorded.All() {
+		perCaller[entry.Caller.File]++
+	}
+	assert.Equal(t, 4, perCaller["service/a.go"])
+	assert.Equal(t, 4, perCaller["service/b.go"])
+}
+
+func TestCallerSamplingCore_Reset(t *testing.T) {
+	obs, recorded := observer.New(zapcore.DebugLevel)
+	core := newCallerSamplingCore(obs, callerSampling{initial: 1, tick: time.Second}, nil, nil)
+
+	caller := zapcore.NewEntryCaller(0, "service/a.go", 10, true)
+	now := time.Now()
+	for _, ts := range []time.Time{now, now, now.Add(2 * time.Second)} {
+		ent := zapcore.Entry{Level: zapcore.InfoLevel, Time: ts, Message: "tick", Caller: caller}
+		if ce := core.Check(ent, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	assert.Equal(t, 2, recorded.Len())
+}
+
+func TestCallerSamplingCore_Hook(t *testing.T) {
+	obs, recorded := observer.New(zapcore.DebugLevel)
+
+	decisions := map[zapcore.SamplingDecision]int{}
+	hook := func(_ zapcore.Entry, decision zapcore.SamplingDecision) {
+		decisions[decision]++
+	}
+	core := newCallerSamplingCore(obs, callerSampling{initial: 5, thereafter: 10, tick: time.Minute}, hook, nil)
+
+	caller := zapcore.NewEntryCaller(0, "service/flood.go", 7, true)
+	now := time.Now()
+	for i := 0; i < 100; i++ {
+		ent := zapcore.Entry{Level: zapcore.InfoLevel, Time: now, Message: "flood", Caller: caller}
+		if ce := core.Check(ent, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	// First 5 entries, then every 10th of the remaining 95
+	assert.Equal(t, 14, decisions[zapcore.LogSampled])
+	assert.Equal(t, 86, decisions[zapcore.LogDropped])
+	assert.Equal(t, decisions[zapcore.LogSampled], recorded.Len())
+}
+
+func TestLevelSamplingCore(t *testing.T) {
+	obs, recorded := observer.New(zapcore.DebugLevel)
+	core := newLevelSamplingCore(obs, levelSampling{maxLevel: zapcore.InfoLevel, initial: 2, thereafter: 0, tick: time.Minute}, nil, nil)
+
+	for i := 0; i < 10; i++ {
+		for _, level := range []zapcore.Level{zapcore.DebugLevel, zapcore.InfoLevel, zapcore.WarnLevel, zapcore.ErrorLevel} {
+			ent := zapcore.Entry{Level: level, Time: time.Now(), Message: "disk almost full"}
+			if ce := core.With(nil).Check(ent, nil); ce != nil {
+				ce.Write()
+			}
+		}
+	}
+
+	perLevel := map[zapcore.Level]int{}
+	for _, entry := range recorded.All() {
+		perLevel[entry.Level]++
+	}
+	assert.Equal(t, 2, perLevel[zapcore.DebugLevel])
+	assert.Equal(t, 2, perLevel[zapcore.InfoLevel])
+	assert.Equal(t, 10, perLevel[zapcore.WarnLevel])
+	assert.Equal(t, 10, perLevel[zapcore.ErrorLevel])
+}
+
+func TestNoSample(t *testing.T) {
+	opt := &option{
+		callerSampling: &callerSampling{initial: 1, tick: time.Minute},
+		levelSampling:  &levelSampling{maxLevel: zapcore.InfoLevel, initial: 1, tick: time.Minute},
+	}
+	obs, recorded := observer.New(zapcore.DebugLevel)
+	logger := &Manager{Zap: zap.New(wrapCore(opt, obs), zap.AddCaller())}
+
+	for i := 0; i < 100; i++ {
+		logger.Info(context.Background(), "request served")
+		logger.Info(context.Background(), "shutting down", NoSample(), zap.Int("i", i))
+		logger.InfoImportant(context.Background(), "leader elected")
+	}
+
+	perMessage := map[string]int{}
+	for _, entry := range recorded.All() {
+		perMessage[entry.Message]++
+	}
+	assert.Equal(t, 1, perMessage["request served"])
+	assert.Equal(t, 100, perMessage["shutting down"])
+	assert.Equal(t, 100, perMessage["leader elected"])
+
+	// The marker is not written
+	last := recorded.FilterMessage("shutting down").All()[99]
+	assert.Equal(t, map[string]interface{}{"i": int64(99)}, last.ContextMap())
+}
+
+func TestWithSamplingExempt(t *testing.T) {
+	for name, sampling := range map[string]Option{
+		"caller": WithCallerSampling(1, 0, time.Minute),
+		"level":  WithLevelSampling(zapcore.WarnLevel, 1, 0, time.Minute),
+	} {
+		t.Run(name, func(t *testing.T) {
+			opt := newOptions(sampling, WithSamplingExempt("audit:", "shutting down"))
+			obs, recorded := observer.New(zapcore.DebugLevel)
+			logger := &Manager{Zap: zap.New(wrapCore(opt, obs), zap.AddCaller(), zap.AddCallerSkip(opt.callerSkip))}
+
+			for i := 0; i < 100; i++ {
+				logger.Info(context.Background(), "request served")
+				logger.Info(context.Background(), "audit: user signed in")
+				logger.Warn(context.Background(), "shutting down in 5s")
+				logger.Info(context.Background(), "not an audit: entry")
+			}
+
+			perMessage := map[string]int{}
+			for _, entry := range recorded.All() {
+				perMessage[entry.Message]++
+			}
+			assert.Equal(t, 1, perMessage["request served"])
+			assert.Equal(t, 100, perMessage["audit: user signed in"])
+			assert.Equal(t, 100, perMessage["shutting down in 5s"])
+			assert.Equal(t, 1, perMessage["not an audit: entry"])
+		})
+	}
+}
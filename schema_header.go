@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/lestrrat-go/file-rotatelogs"
+	"go.uber.org/zap/zapcore"
+)
+
+// schemaHeaderSyncer writes a header line at the start of every new file of a rotatelogs hook
+type schemaHeaderSyncer struct {
+	mu      sync.Mutex
+	hook    *rotatelogs.RotateLogs
+	out     zapcore.WriteSyncer // Writer of the header and entries, the hook or an encrypting wrapper
+	header  []byte
+	current string // File the previous write went to
+}
+
+// WithSchemaHeader writes a header line at the start of every new log file
+//
+// The header lets custom parsers identify the format of a file, e.g. a JSON object with
+// the schema name and version. It is written as the first line of each file created by
+// the file driver, including the files created by rotation and by WithSplitByLevel,
+// and never in the middle of a file: a file that already holds entries when the logger
+// starts, e.g. after a restart, is appended to without a header. A trailing newline is
+// added when missing.
+// Only used when driver is "file", not with WithPlainFile.
+//
+// Parameters:
+//   - schema: The header line, e.g. `{"schema":"orders-log","version":2}`
+//
+// Returns:
+//   - Option: A function that sets the schema header in the option struct
+func WithSchemaHeader(schema string) Option {
+	return func(o *option) {
+		o.schemaHeader = schema
+	}
+}
+
+// newSchemaHeaderSyncer wraps a rotatelogs hook to start each new file with a header
+//
+// Parameters:
+//   - hook: The hook writing the rotated files
+//   - out: The syncer writing to the hook
+//   - header: The header line
+//
+// Returns:
+//   - *schemaHeaderSyncer: A syncer writing the header before the first entry of each file
+func newSchemaHeaderSyncer(hook *rotatelogs.RotateLogs, out zapcore.WriteSyncer, header string) *schemaHeaderSyncer {
+	if !strings.HasSuffix(header, "\n") {
+		header += "\n"
+	}
+
+	return &schemaHeaderSyncer{hook: hook, out: out, header: []byte(header)}
+}
+
+// Write implements zapcore.WriteSyncer, writing the header first when the entry starts a new file
+func (s *schemaHeaderSyncer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// An empty write makes the hook open the file the entry belongs to, rotating if due
+	if _, err := s.hook.Write(nil); err != nil {
+		return 0, err
+	}
+
+	if name := s.hook.CurrentFileName(); name != s.current {
+		s.current = name
+		if info, err := os.Stat(name); err == nil && info.Size() == 0 {
+			if _, err := s.out.Write(s.header); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return s.out.Write(p)
+}
+
+// Sync implements zapcore.WriteSyncer, syncing the wrapped syncer
+func (s *schemaHeaderSyncer) Sync() error {
+	return s.out.Sync()
+}
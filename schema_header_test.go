@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/file-rotatelogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+const testSchemaHeader = `{"schema":"orders-log","version":2}`
+
+func TestSchemaHeaderSyncer_Rotation(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 1, 2, 10, 30, 0, 0, time.Local)
+	clock := funcClock(func() time.Time { return now })
+
+	hook, err := rotatelogs.New(filepath.Join(dir, "%Y%m%d%H.log"), rotatelogs.WithRotationTime(time.Hour), rotatelogs.WithClock(clock))
+	require.NoError(t, err)
+	defer hook.Close()
+	syncer := newSchemaHeaderSyncer(hook, zapcore.AddSync(hook), testSchemaHeader)
+
+	write := func(entry string) {
+		_, err := syncer.Write([]byte(entry))
+		require.NoError(t, err)
+	}
+
+	write("first\n")
+	write("second\n")
+	now = now.Add(time.Hour)
+	write("third\n")
+
+	assert.Equal(t, testSchemaHeader+"\nfirst\nsecond\n", readFile(t, filepath.Join(dir, "2024010210.log")))
+	assert.Equal(t, testSchemaHeader+"\nthird\n", readFile(t, filepath.Join(dir, "2024010211.log")))
+}
+
+func TestWithSchemaHeader(t *testing.T) {
+	dir := t.TempDir() + string(filepath.Separator)
+	logger, err := New(WithDriver("file"), WithLogPath(dir), WithSchemaHeader(testSchemaHeader))
+	require.NoError(t, err)
+
+	logger.Info(context.Background(), "order created")
+	logger.Info(context.Background(), "order paid")
+	require.NoError(t, logger.Close())
+
+	lines := strings.Split(strings.TrimSpace(readFile(t, dir+time.Now().Format("2006-01-02")+".log")), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, testSchemaHeader, lines[0])
+	assert.Contains(t, lines[1], "order created")
+	assert.Contains(t, lines[2], "order paid")
+}
+
+func TestWithSchemaHeader_ExistingFile(t *testing.T) {
+	dir := t.TempDir() + string(filepath.Separator)
+	file := dir + time.Now().Format("2006-01-02") + ".log"
+	require.NoError(t, os.WriteFile(file, []byte("written before the restart\n"), 0o600))
+
+	logger, err := New(WithDriver("file"), WithLogPath(dir), WithSchemaHeader(testSchemaHeader))
+	require.NoError(t, err)
+	logger.Info(context.Background(), "order created")
+	require.NoError(t, logger.Close())
+
+	content := readFile(t, file)
+	assert.NotContains(t, content, testSchemaHeader, "no header in the middle of a file")
+	assert.Contains(t, content, "order created")
+}
+
+// readFile returns the content of a file
+func readFile(t *testing.T, name string) string {
+	content, err := os.ReadFile(name)
+	require.NoError(t, err)
+
+	return string(content)
+}
@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// selfTestMessage is the message of the entry written by WithStartupSelfTest
+const selfTestMessage = "logger self-test"
+
+// WithStartupSelfTest makes New fail when the outputs cannot be written
+//
+// New writes a probe entry, at InfoLevel with the message "logger self-test", to every
+// output and returns the error of the first failing write. A missing permission, a full
+// disk or a path that cannot be created is reported at startup instead of on the first
+// log call. The probe is written whatever the configured level. Reconfigure runs it too,
+// since it may open new outputs, while SetEncoding, which keeps the outputs, does not.
+// Outputs that buffer or export asynchronously, such as WithOTLP, only accept the entry.
+//
+// Returns:
+//   - Option: A function that enables the startup self-test in the option struct
+func WithStartupSelfTest() Option {
+	return func(o *option) {
+		o.startupSelfTest = true
+	}
+}
+
+// newSelfTestedCore creates the core like newCore, first writing the probe entry to its outputs when enabled
+//
+// Parameters:
+//   - opt: The option struct containing configuration
+//   - level: The zap.AtomicLevel for dynamic level changes
+//
+// Returns:
+//   - zapcore.Core: The core wrapped with every enabled feature
+//   - error: An error if the core cannot be created or the self-test fails
+func newSelfTestedCore(opt *option, level zap.AtomicLevel) (zapcore.Core, error) {
+	core, err := newOutputCore(opt, level)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.startupSelfTest {
+		if err := selfTest(core); err != nil {
+			return nil, err
+		}
+	}
+
+	return wrapCore(opt, core), nil
+}
+
+// selfTest writes the probe entry to every output of a core
+//
+// Parameters:
+//   - core: The core writing to the outputs, before the feature wrappers
+//
+// Returns:
+//   - error: An error if writing to any output fails
+func selfTest(core zapcore.Core) error {
+	ent := zapcore.Entry{Level: zapcore.InfoLevel, Time: time.Now(), Message: selfTestMessage}
+	if err := core.Write(ent, nil); err != nil {
+		return fmt.Errorf("startup self-test failed: %w", err)
+	}
+
+	return nil
+}
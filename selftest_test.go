@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithStartupSelfTest_ReadOnlyDir(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root ignores directory permissions")
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, os.Chmod(dir, 0o500))
+	t.Cleanup(func() { _ = os.Chmod(dir, 0o700) })
+
+	_, err := New(WithDriver("file"), WithLogPath(dir+string(filepath.Separator)), WithStartupSelfTest())
+	assert.ErrorContains(t, err, "startup self-test failed")
+}
+
+func TestWithStartupSelfTest_UncreatablePath(t *testing.T) {
+	// A regular file where a directory is expected cannot be created, even by root
+	blocker := filepath.Join(t.TempDir(), "blocker")
+	require.NoError(t, os.WriteFile(blocker, nil, 0o600))
+	path := filepath.Join(blocker, "logs") + string(filepath.Separator)
+
+	_, err := New(WithDriver("file"), WithLogPath(path), WithStartupSelfTest())
+	assert.ErrorContains(t, err, "startup self-test failed")
+
+	// Without the self-test, the error only shows up when logging
+	logger, err := New(WithDriver("file"), WithLogPath(path))
+	require.NoError(t, err)
+	assert.NoError(t, logger.Close())
+}
+
+func TestWithStartupSelfTest_Writable(t *testing.T) {
+	core, recorded := observer.New(zapcore.ErrorLevel)
+	dir := t.TempDir() + string(filepath.Separator)
+
+	logger, err := New(WithDriver("file"), WithLogPath(dir), WithLevel("error"), WithExtraCore(core), WithStartupSelfTest())
+	require.NoError(t, err)
+	defer logger.Close()
+
+	entries := recorded.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, selfTestMessage, entries[0].Message)
+}
+
+func TestWithStartupSelfTest_Rebuild(t *testing.T) {
+	core, recorded := observer.New(zapcore.ErrorLevel)
+	options := []Option{WithDriver("file"), WithLogPath(t.TempDir() + string(filepath.Separator)), WithLevel("error"), WithExtraCore(core), WithStartupSelfTest()}
+
+	logger, err := New(options...)
+	require.NoError(t, err)
+	defer logger.Close()
+	require.Len(t, recorded.FilterMessage(selfTestMessage).All(), 1)
+
+	// The outputs are kept, so the probe is not written again
+	require.NoError(t, logger.SetEncoding("console"))
+	assert.Len(t, recorded.FilterMessage(selfTestMessage).All(), 1)
+
+	// The new configuration may open new outputs, so it is tested again
+	require.NoError(t, logger.Reconfigure(options...))
+	assert.Len(t, recorded.FilterMessage(selfTestMessage).All(), 2)
+}
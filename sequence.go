@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// sequence is the process-wide counter numbering the entries of WithSequence
+var sequence atomic.Uint64
+
+// sequenceCore is a zapcore.Core numbering every written entry
+type sequenceCore struct {
+	zapcore.Core
+	levelChecked
+}
+
+// WithSequence adds a process-wide, monotonically increasing "seq" field to every entry
+//
+// The number is assigned when the entry is written, after level filtering and sampling,
+// so suppressed entries do not consume numbers. Unlike timestamps, it gives a total order
+// of the entries across goroutines and across every manager of the process.
+//
+// Returns:
+//   - Option: A function that enables sequence numbers in the option struct
+func WithSequence() Option {
+	return func(o *option) {
+		o.sequence = true
+	}
+}
+
+// newSequenceCore wraps a core to number every written entry
+//
+// Parameters:
+//   - core: The core to wrap
+//
+// Returns:
+//   - zapcore.Core: A core adding the "seq" field
+func newSequenceCore(core zapcore.Core) zapcore.Core {
+	return &sequenceCore{Core: core}
+}
+
+// With adds structured context to the core
+func (c *sequenceCore) With(fields []zapcore.Field) zapcore.Core {
+	return &sequenceCore{Core: c.Core.With(fields)}
+}
+
+// Check adds the core so the number is only assigned to entries that are written
+func (c *sequenceCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write writes the entry with the next sequence number prepended
+func (c *sequenceCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	all := make([]zapcore.Field, 0, len(fields)+1)
+	all = append(all, zap.Uint64("seq", sequence.Add(1)))
+	all = append(all, fields...)
+
+	return writeEntry(c.Core, ent, all)
+}
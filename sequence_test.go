@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithSequence(t *testing.T) {
+	const workers, perWorker = 8, 50
+
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger := &Manager{Zap: zap.New(wrapCore(&option{sequence: true}, core))}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				logger.Debug(context.Background(), "suppressed")
+				logger.Info(context.Background(), "step", zap.Int("worker", worker))
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	entries := recorded.All()
+	require.Len(t, entries, workers*perWorker)
+
+	var all []uint64
+	last := make(map[int64]uint64)
+	for _, entry := range entries {
+		fields := entry.ContextMap()
+		seq := fields["seq"].(uint64)
+		worker := fields["worker"].(int64)
+
+		assert.Greater(t, seq, last[worker], "sequence increases within a goroutine")
+		last[worker] = seq
+		all = append(all, seq)
+	}
+
+	// Suppressed entries consume no numbers, so the written ones are contiguous
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+	for i := 1; i < len(all); i++ {
+		assert.Equal(t, all[i-1]+1, all[i])
+	}
+}
@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"io"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// sink is an additional output with its own encoding and level
+type sink struct {
+	encoding string
+	useColor bool
+	writer   io.Writer
+	level    zapcore.Level
+}
+
+// WithSinkEncoded writes the entries to an additional writer with its own encoding
+//
+// Each sink gets a core with its own encoder, teed with the configured driver, e.g. the
+// console encoding on stdout and a JSON copy in a file. The sink records the entries at or
+// above level, independently of the manager's level, like cores added with WithExtraCore.
+// The encoder configuration is shared with the driver; useColor colors the level when the
+// encoding is "console". The option can be given several times.
+//
+// Parameters:
+//   - encoding: The encoding of the sink: "json", "console", "msgpack" or "gelf"
+//   - useColor: Whether to color the level (only for the console encoding)
+//   - w: The writer receiving the encoded entries
+//   - level: The minimum level of the entries written to the sink
+//
+// Returns:
+//   - Option: A function that adds the sink to the option struct
+func WithSinkEncoded(encoding string, useColor bool, w io.Writer, level zapcore.Level) Option {
+	return func(o *option) {
+		o.sinks = append(o.sinks, sink{encoding: encoding, useColor: useColor, writer: w, level: level})
+	}
+}
+
+// newSinkCores creates a core per sink
+//
+// Parameters:
+//   - opt: The option struct containing configuration
+//
+// Returns:
+//   - []zapcore.Core: The cores writing to the sinks
+//   - error: An error if the encoding of a sink is unknown
+func newSinkCores(opt *option) ([]zapcore.Core, error) {
+	cores := make([]zapcore.Core, 0, len(opt.sinks))
+	for _, s := range opt.sinks {
+		config := opt.encoderConfig
+		if s.useColor && s.encoding == "console" {
+			config.EncodeLevel = levelEncoder(&option{
+				encoderConfig: zapcore.EncoderConfig{EncodeLevel: zapcore.CapitalColorLevelEncoder},
+				levelStrings:  opt.levelStrings,
+			})
+		}
+
+		encoder, err := newEncoder(&option{encoding: s.encoding, encoderConfig: config})
+		if err != nil {
+			return nil, err
+		}
+		cores = append(cores, newDirectCore(zapcore.NewCore(encoder, zapcore.AddSync(s.writer), s.level)))
+	}
+
+	return cores, nil
+}
@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithSinkEncoded(t *testing.T) {
+	jsonOut := &bytes.Buffer{}
+	consoleOut := &bytes.Buffer{}
+
+	logger, err := New(
+		WithLevel("error"),
+		WithSinkEncoded("json", false, jsonOut, zapcore.InfoLevel),
+		WithSinkEncoded("console", false, consoleOut, zapcore.WarnLevel),
+	)
+	require.NoError(t, err)
+	defer logger.Close()
+
+	ctx := context.Background()
+	logger.Info(ctx, "order created", zap.String("order", "o-1"))
+	logger.Warn(ctx, "stock low", zap.Int("left", 2))
+
+	lines := strings.Split(strings.TrimSpace(jsonOut.String()), "\n")
+	require.Len(t, lines, 2)
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "order created", entry["M"])
+	assert.Equal(t, "o-1", entry["order"])
+
+	lines = strings.Split(strings.TrimSpace(consoleOut.String()), "\n")
+	require.Len(t, lines, 1)
+	assert.Contains(t, lines[0], "\tWARN\t")
+	assert.Contains(t, lines[0], "\tstock low\t")
+	assert.Contains(t, lines[0], `{"left": 2}`)
+}
+
+func TestWithSinkEncoded_Color(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger, err := New(WithLevel("error"), WithSinkEncoded("console", true, out, zapcore.InfoLevel))
+	require.NoError(t, err)
+	defer logger.Close()
+
+	logger.Info(context.Background(), "colored")
+	assert.Contains(t, out.String(), "\x1b[34mINFO\x1b[0m")
+}
+
+func TestWithSinkEncoded_UnknownEncoding(t *testing.T) {
+	_, err := New(WithSinkEncoded("xml", false, &bytes.Buffer{}, zapcore.InfoLevel))
+	assert.Error(t, err)
+}
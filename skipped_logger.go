@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+type (
+	// skippedLoggerCache caches the logger with the caller skip applied
+	//
+	// Applying zap.AddCallerSkip clones the logger, so doing it on every log call costs
+	// two allocations. The cache is rebuilt whenever the base logger or the skip changes.
+	skippedLoggerCache struct {
+		current atomic.Pointer[skippedLogger]
+	}
+
+	// skippedLogger is a logger with the caller skip applied, keyed by its base logger and skip
+	skippedLogger struct {
+		base   *zap.Logger
+		skip   int
+		logger *zap.Logger
+	}
+)
+
+// get returns base with skip additional caller frames skipped
+//
+// Parameters:
+//   - base: The logger the caller skip is applied to
+//   - skip: The number of frames to skip
+//
+// Returns:
+//   - *zap.Logger: The logger with the caller skip applied, a fresh clone when the cache is nil
+func (c *skippedLoggerCache) get(base *zap.Logger, skip int) *zap.Logger {
+	if c == nil {
+		return base.WithOptions(zap.AddCallerSkip(skip))
+	}
+
+	if s := c.current.Load(); s != nil && s.base == base && s.skip == skip {
+		return s.logger
+	}
+
+	logger := base.WithOptions(zap.AddCallerSkip(skip))
+	c.current.Store(&skippedLogger{base: base, skip: skip, logger: logger})
+
+	return logger
+}
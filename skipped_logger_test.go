@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSkippedLoggerCache_Get(t *testing.T) {
+	cache := &skippedLoggerCache{}
+	base := zap.NewNop()
+
+	first := cache.get(base, 1)
+	assert.Same(t, first, cache.get(base, 1))
+	assert.NotSame(t, first, cache.get(base, 2))
+
+	other := zap.NewNop()
+	assert.NotSame(t, cache.get(base, 2), cache.get(other, 2))
+
+	var disabled *skippedLoggerCache
+	assert.NotSame(t, disabled.get(base, 1), disabled.get(base, 1))
+}
+
+func TestManager_SkipCacheFollowsCallerSkip(t *testing.T) {
+	core, logs := observer.New(InfoLevel)
+	logger := &Manager{
+		Zap:        zap.New(core, zap.AddCaller()),
+		callerSkip: NewCallerSkip(1),
+		skipCache:  &skippedLoggerCache{},
+	}
+
+	logger.Info(context.Background(), "direct")
+	logger.SetCallerSkip(2)
+	logHelper(logger)
+
+	entries := logs.AllUntimed()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, entries[0].Caller.Line, entries[1].Caller.Line-2)
+}
+
+// logHelper logs from a helper, so its caller is the line calling it
+func logHelper(logger *Manager) {
+	logger.Info(context.Background(), "helper")
+}
+
+func TestManager_InfoAllocations(t *testing.T) {
+	logger := &Manager{
+		Zap:        zap.NewNop(),
+		callerSkip: NewCallerSkip(1),
+		skipCache:  &skippedLoggerCache{},
+	}
+	ctx := context.Background()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		logger.Info(ctx, "message")
+	})
+	assert.Zero(t, allocs)
+}
@@ -0,0 +1,107 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// SpanIDKey is the context key of the span ID set by StartSpan
+	SpanIDKey = "span_id"
+
+	// SpanIDField is the key of the field holding the span ID
+	SpanIDField = "SpanID"
+
+	// SpanDepthKey is the context key of the nesting depth set by StartSpan
+	SpanDepthKey = "span_depth"
+
+	// SpanDepthField is the key of the field holding the nesting depth of the span
+	SpanDepthField = "depth"
+)
+
+// StartSpan logs the start of a span and returns a context carrying its span ID
+//
+// This gives lightweight timing traces without a tracer: a "span.start" entry is logged
+// with the span name, and the returned function logs a "span.end" entry with the duration
+// in "duration_ms". Both entries, and every entry logged with the returned context, carry
+// the span ID as SpanIDField and the nesting depth as SpanDepthField: 0 for a top-level
+// span, one more than its parent for a nested span. A span started from the context of
+// another span records it as "parent_span_id". Entries logged with the parent's context
+// after the nested span keep the parent's depth. Span IDs are 16 random hex digits, the
+// OpenTelemetry format.
+//
+// Parameters:
+//   - ctx: The context.Context of the span
+//   - name: The name of the span
+//
+// Returns:
+//   - context.Context: The context carrying the span ID
+//   - func(): A function logging the end of the span, to be called once
+func (m *Manager) StartSpan(ctx context.Context, name string) (context.Context, func()) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	fields := []zap.Field{zap.String("span", name)}
+	depth := 0
+	if parent := getSpanIDFromContext(ctx); parent != "" {
+		fields = append(fields, zap.String("parent_span_id", parent))
+		if parentDepth, ok := getSpanDepthFromContext(ctx); ok {
+			depth = parentDepth + 1
+		}
+	}
+
+	ctx = context.WithValue(ctx, SpanIDKey, newSpanID())
+	ctx = context.WithValue(ctx, SpanDepthKey, depth)
+	start := time.Now()
+	m.getLoggerWithTraceID(ctx, fields...).Info("span.start", fields...)
+
+	return ctx, func() {
+		fields := []zap.Field{zap.String("span", name), Latency("duration_ms", time.Since(start))}
+		m.getLoggerWithTraceID(ctx, fields...).Info("span.end", fields...)
+	}
+}
+
+// getSpanIDFromContext extracts the span ID from the context
+//
+// Parameters:
+//   - ctx: The context.Context to extract the span ID from
+//
+// Returns:
+//   - string: The extracted span ID, or an empty string if not found
+func getSpanIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if spanID, ok := ctx.Value(SpanIDKey).(string); ok {
+		return spanID
+	}
+	return ""
+}
+
+// getSpanDepthFromContext extracts the span nesting depth from the context
+//
+// Parameters:
+//   - ctx: The context.Context to extract the depth from
+//
+// Returns:
+//   - int: The depth of the current span
+//   - bool: Whether the context carries a depth
+func getSpanDepthFromContext(ctx context.Context) (int, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	depth, ok := ctx.Value(SpanDepthKey).(int)
+	return depth, ok
+}
+
+// newSpanID returns 8 random bytes in hex
+func newSpanID() string {
+	var id [8]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestManager_StartSpan(t *testing.T) {
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger := &Manager{
+		Zap:        zap.New(core, zap.AddCaller()),
+		callerSkip: NewCallerSkip(defaultCallerSkip),
+	}
+
+	ctx, finish := logger.StartSpan(context.Background(), "load user")
+	logger.Info(ctx, "inside")
+	childCtx, finishChild := logger.StartSpan(ctx, "query")
+	finishChild()
+	finish()
+
+	entries := recorded.All()
+	require.Len(t, entries, 5)
+
+	start, inside, childStart, childEnd, end := entries[0], entries[1], entries[2], entries[3], entries[4]
+	spanID := start.ContextMap()[SpanIDField]
+	assert.Len(t, spanID, 16)
+	assert.Equal(t, "span.start", start.Message)
+	assert.Equal(t, "load user", start.ContextMap()["span"])
+	assert.Equal(t, spanID, inside.ContextMap()[SpanIDField])
+	assert.Equal(t, spanID, getSpanIDFromContext(ctx))
+
+	assert.Equal(t, "span.end", end.Message)
+	assert.Equal(t, spanID, end.ContextMap()[SpanIDField])
+	assert.IsType(t, float64(0), end.ContextMap()["duration_ms"])
+	assert.True(t, strings.HasSuffix(end.Caller.File, "span_test.go"))
+
+	childID := childStart.ContextMap()[SpanIDField]
+	assert.NotEqual(t, spanID, childID)
+	assert.Equal(t, spanID, childStart.ContextMap()["parent_span_id"])
+	assert.Equal(t, childID, childEnd.ContextMap()[SpanIDField])
+	assert.Equal(t, childID, getSpanIDFromContext(childCtx))
+}
+
+func TestManager_StartSpanDepth(t *testing.T) {
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger := &Manager{Zap: zap.New(core)}
+
+	ctx, finish := logger.StartSpan(context.Background(), "request")
+	childCtx, finishChild := logger.StartSpan(ctx, "service")
+	_, finishGrandchild := logger.StartSpan(childCtx, "query")
+	logger.Info(childCtx, "in service")
+	finishGrandchild()
+	finishChild()
+	logger.Info(ctx, "back in request")
+	finish()
+	logger.Info(context.Background(), "outside")
+
+	depths := map[string][]interface{}{}
+	for _, entry := range recorded.All() {
+		key := entry.Message
+		if span, ok := entry.ContextMap()["span"]; ok {
+			key += " " + span.(string)
+		}
+		depths[key] = append(depths[key], entry.ContextMap()[SpanDepthField])
+	}
+
+	assert.Equal(t, map[string][]interface{}{
+		"span.start request": {int64(0)},
+		"span.start service": {int64(1)},
+		"span.start query":   {int64(2)},
+		"in service":         {int64(1)},
+		"span.end query":     {int64(2)},
+		"span.end service":   {int64(1)},
+		"back in request":    {int64(0)},
+		"span.end request":   {int64(0)},
+		"outside":            {nil},
+	}, depths)
+}
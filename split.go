@@ -0,0 +1,99 @@
+package logger
+
+import (
+	"path/filepath"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// levelBucket routes a range of levels to its own file
+type levelBucket struct {
+	name    string                   // File name without extension
+	enabled func(zapcore.Level) bool // Levels written to the file
+}
+
+// levelBuckets are the files created by WithSplitByLevel
+var levelBuckets = []levelBucket{
+	{name: "error", enabled: func(l zapcore.Level) bool { return l >= ErrorLevel }},
+	{name: "warn", enabled: func(l zapcore.Level) bool { return l == WarnLevel }},
+	{name: "info", enabled: func(l zapcore.Level) bool { return l == InfoLevel }},
+	{name: "debug", enabled: func(l zapcore.Level) bool { return l <= DebugLevel }},
+}
+
+// WithSplitByLevel writes one file per level bucket under a date-partitioned directory
+//
+// Files are laid out as <baseDir>/<YYYY-MM-DD>/{error,warn,info,debug}.log, where error.log
+// holds Error and above. Every bucket has its own rotation hook, so up to four files are
+// open at once and four new files appear per rotation period. Old files are removed after
+// maxAge, but the emptied date directories are left in place.
+// Only used when driver is "file", in which case WithLogPath is ignored.
+//
+// Parameters:
+//   - baseDir: The directory holding the date partitions
+//
+// Returns:
+//   - Option: A function that sets the split directory in the option struct
+func WithSplitByLevel(baseDir string) Option {
+	return func(o *option) {
+		o.splitByLevelDir = baseDir
+		o.flatLevelFiles = false
+	}
+}
+
+// WithSeparateLevelFiles writes the entries of each level to its own rotated file in dir
+//
+// This selects the "file" driver. The files debug.log, info.log, warn.log and error.log in
+// dir always point to the current file of their level: they are symlinks to the rotated
+// files written beside them, e.g. <dir>/error.<YYYY-MM-DD>.log. The files are not cumulative,
+// each one only holds the entries of its own level: an Error entry is only written to
+// error.log, not to warn.log or info.log. error.log also holds the DPanic, Panic and Fatal
+// entries, debug.log the Trace entries. The level set by WithLevel still applies, e.g.
+// debug.log is not created at Info. WithLogPath is ignored.
+//
+// Parameters:
+//   - dir: The directory holding the level files
+//
+// Returns:
+//   - Option: A function that sets the file driver and the level files directory in the option struct
+func WithSeparateLevelFiles(dir string) Option {
+	return func(o *option) {
+		WithDriver("file")(o)
+		WithSplitByLevel(dir)(o)
+		o.flatLevelFiles = true
+	}
+}
+
+// newSplitFileCore creates a tee of file cores, one per level bucket
+//
+// Parameters:
+//   - opt: The option struct containing configuration
+//   - encoder: The zapcore.Encoder to use
+//   - level: The zap.AtomicLevel for dynamic level changes
+//
+// Returns:
+//   - zapcore.Core: A tee writing each level to its bucket's file
+//   - error: An error if a rotation hook cannot be created
+func newSplitFileCore(opt *option, encoder zapcore.Encoder, level zap.AtomicLevel) (zapcore.Core, error) {
+	patterns := logFilePatterns(opt)
+	cores := make([]zapcore.Core, 0, len(levelBuckets))
+	for i, bucket := range levelBuckets {
+		var linkName string
+		if opt.flatLevelFiles {
+			linkName = filepath.Join(opt.splitByLevelDir, bucket.name+".log")
+		}
+
+		syncer, err := newFileSyncer(opt, patterns[i], linkName)
+		if err != nil {
+			return nil, err
+		}
+
+		enabled := bucket.enabled
+		enabler := zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+			return level.Enabled(l) && enabled(l)
+		})
+		cores = append(cores, newDirectCore(zapcore.NewCore(encoder.Clone(), syncer, enabler)))
+	}
+
+	return newTeeCore(cores...), nil
+}
@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSplitByLevel(t *testing.T) {
+	skipWithoutDebug(t)
+
+	dir := t.TempDir()
+	logger, err := New(
+		WithDriver("file"),
+		WithLevel("debug"),
+		WithSplitByLevel(dir),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	logger.Debug(ctx, "debug entry")
+	logger.Info(ctx, "info entry")
+	logger.Warn(ctx, "warn entry")
+	logger.Error(ctx, "error entry")
+	require.NoError(t, logger.Sync())
+
+	dateDir := filepath.Join(dir, time.Now().Format("2006-01-02"))
+	for _, name := range []string{"debug", "info", "warn", "error"} {
+		content, err := os.ReadFile(filepath.Join(dateDir, name+".log"))
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		assert.Len(t, lines, 1, name)
+		assert.Contains(t, lines[0], name+" entry")
+	}
+}
+
+func TestWithSeparateLevelFiles(t *testing.T) {
+	dir := t.TempDir()
+	logger, err := New(WithSeparateLevelFiles(dir))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	logger.Info(ctx, "info entry")
+	logger.Warn(ctx, "warn entry")
+	logger.Error(ctx, "error entry")
+	require.NoError(t, logger.Close())
+
+	read := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(dir, name+".log"))
+		if os.IsNotExist(err) {
+			return ""
+		}
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	assert.Contains(t, read("error"), "error entry")
+	for _, name := range []string{"debug", "info", "warn"} {
+		assert.NotContains(t, read(name), "error entry", "the files are not cumulative")
+	}
+	assert.Contains(t, read("warn"), "warn entry")
+	assert.NotContains(t, read("info"), "warn entry")
+	assert.Contains(t, read("info"), "info entry")
+
+	dated, err := os.ReadFile(filepath.Join(dir, "error."+time.Now().Format("2006-01-02")+".log"))
+	require.NoError(t, err)
+	assert.Equal(t, read("error"), string(dated), "error.log points to the current file")
+}
@@ -0,0 +1,280 @@
+package logger
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// SQLMaxQueryLength is the maximum number of query bytes logged by SQL
+	SQLMaxQueryLength = 1024
+
+	// SQLMaxArgLength is the maximum number of bytes logged per string argument by SQL
+	SQLMaxArgLength = 64
+
+	// sqlRedacted replaces the arguments that look sensitive
+	sqlRedacted = "[REDACTED]"
+)
+
+var (
+	// sqlInsertColumns matches the column list of an INSERT ... VALUES statement
+	sqlInsertColumns = regexp.MustCompile(`(?is)insert\s+into\s+[^\s(]+\s*\(([^)]*)\)\s*values`)
+
+	// sqlSensitiveColumns are the column name fragments whose arguments are redacted
+	sqlSensitiveColumns = []string{"password", "passwd", "secret", "token", "apikey", "api_key", "credential", "card_number", "cardnumber", "private_key"}
+
+	// sqlSensitiveExactColumns are the short column names whose arguments are redacted
+	sqlSensitiveExactColumns = []string{"pwd", "pin", "ssn", "cvv", "cvc"}
+)
+
+// SQL constructs the fields describing an executed SQL query
+//
+// The fields are "query", with whitespace collapsed and truncated to SQLMaxQueryLength
+// bytes, "args", one string per argument, and "duration_ms" as written by Latency.
+// Arguments bound to a sensitive-looking column (e.g. password = ?, or a password column
+// of an INSERT) and values that look like tokens or card numbers are replaced by
+// "[REDACTED]". Strings are quoted and truncated to SQLMaxArgLength bytes, byte slices
+// are summarized by their length.
+//
+// Parameters:
+//   - query: The SQL query, with ?, $N, :name or @name placeholders
+//   - args: The query arguments, sql.NamedArg values are matched by name
+//   - duration: The time the query took
+//
+// Returns:
+//   - []zap.Field: The query, args and duration_ms fields
+func SQL(query string, args []interface{}, duration time.Duration) []zap.Field {
+	columns := sqlParamColumns(query)
+
+	summaries := make([]string, len(args))
+	for i, arg := range args {
+		column := columns[i]
+		if named, ok := arg.(sql.NamedArg); ok {
+			column, arg = strings.ToLower(named.Name), named.Value
+		}
+		summaries[i] = sqlArgSummary(column, arg)
+	}
+
+	return []zap.Field{
+		zap.String("query", truncateString(strings.Join(strings.Fields(query), " "), SQLMaxQueryLength)),
+		zap.Strings("args", summaries),
+		Latency("duration_ms", duration),
+	}
+}
+
+// sqlArgSummary renders an argument, redacting it if its column or value looks sensitive
+func sqlArgSummary(column string, arg interface{}) string {
+	if isSensitiveColumn(column) {
+		return sqlRedacted
+	}
+
+	switch v := arg.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		if isSensitiveValue(v) {
+			return sqlRedacted
+		}
+		return strconv.Quote(truncateString(v, SQLMaxArgLength))
+	case []byte:
+		return "<" + strconv.Itoa(len(v)) + " bytes>"
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case fmt.Stringer:
+		return strconv.Quote(truncateString(v.String(), SQLMaxArgLength))
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		s := fmt.Sprint(v)
+		if isSensitiveValue(s) {
+			return sqlRedacted
+		}
+		return s
+	}
+
+	return fmt.Sprintf("<%T>", arg)
+}
+
+// sqlParamColumns maps the index of each argument to the column it is bound to, if known
+//
+// A placeholder is bound to the identifier before its comparison or assignment operator,
+// e.g. "password" in "password = ?", or to its column in the column list of an INSERT.
+// Placeholders inside quoted literals are ignored.
+func sqlParamColumns(query string) map[int]string {
+	columns := make(map[int]string)
+	var insertValues []string
+	if m := sqlInsertColumns.FindStringSubmatch(query); m != nil {
+		insertValues = strings.Split(m[1], ",")
+	}
+
+	next := 0            // Index of the next ? and named placeholder
+	inValues := -1       // Position within the current VALUES row, -1 outside of it
+	depth := 0           // Parentheses opened within the current VALUES row
+	afterValues := false // Whether the first VALUES row has been seen
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+			continue
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+			continue
+		}
+
+		index, end := -1, i
+		switch {
+		case c == '?':
+			index = next
+			next++
+		case c == '$':
+			for end+1 < len(query) && query[end+1] >= '0' && query[end+1] <= '9' {
+				end++
+			}
+			if end > i {
+				n, _ := strconv.Atoi(query[i+1 : end+1])
+				index = n - 1
+			}
+		case (c == ':' || c == '@') && i+1 < len(query) && isIdentByte(query[i+1]) && (i == 0 || query[i-1] != ':'):
+			for end+1 < len(query) && isIdentByte(query[end+1]) {
+				end++
+			}
+			index = next
+			next++
+		case c == '(' && inValues >= 0:
+			depth++
+		case c == '(' && insertValues != nil && isValuesRowStart(query[:i], afterValues):
+			inValues, depth, afterValues = 0, 0, true
+		case c == ',' && inValues >= 0 && depth == 0:
+			inValues++
+		case c == ')' && inValues >= 0:
+			if depth == 0 {
+				inValues = -1
+			}
+			depth--
+		}
+		if index < 0 {
+			continue
+		}
+
+		column := precedingIdent(query[:i])
+		if inValues >= 0 && inValues < len(insertValues) {
+			column = insertValues[inValues]
+		}
+		columns[index] = normalizeColumn(column)
+		i = end
+	}
+
+	return columns
+}
+
+// isValuesRowStart reports whether an opening parenthesis after prefix starts a row of VALUES
+func isValuesRowStart(prefix string, afterValues bool) bool {
+	prefix = strings.TrimSpace(prefix)
+	if afterValues {
+		return strings.HasSuffix(prefix, ",")
+	}
+
+	return strings.HasSuffix(strings.ToUpper(prefix), "VALUES")
+}
+
+// precedingIdent returns the identifier before the operator ending s, e.g. "u.name" in "u.name <> "
+func precedingIdent(s string) string {
+	s = strings.TrimRight(s, " \t\r\n=<>!")
+	start := len(s)
+	for start > 0 && (isIdentByte(s[start-1]) || strings.IndexByte(".\"`", s[start-1]) >= 0) {
+		start--
+	}
+
+	return s[start:]
+}
+
+// normalizeColumn strips the table qualifier, quotes and case from a column name
+func normalizeColumn(column string) string {
+	column = strings.TrimSpace(column)
+	if i := strings.LastIndexByte(column, '.'); i >= 0 {
+		column = column[i+1:]
+	}
+
+	return strings.ToLower(strings.Trim(column, "\"`"))
+}
+
+// isIdentByte reports whether c may be part of an unquoted SQL identifier
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// isSensitiveColumn reports whether a column name suggests a secret
+func isSensitiveColumn(column string) bool {
+	for _, name := range sqlSensitiveExactColumns {
+		if column == name {
+			return true
+		}
+	}
+	for _, fragment := range sqlSensitiveColumns {
+		if strings.Contains(column, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSensitiveValue reports whether a value looks like a bearer token, a JWT or a card number
+func isSensitiveValue(v string) bool {
+	if strings.HasPrefix(v, "Bearer ") || strings.HasPrefix(v, "eyJ") && strings.Count(v, ".") == 2 {
+		return true
+	}
+
+	digits := make([]byte, 0, len(v))
+	for i := 0; i < len(v); i++ {
+		switch c := v[i]; {
+		case c >= '0' && c <= '9':
+			digits = append(digits, c)
+		case c == ' ' || c == '-':
+		default:
+			return false
+		}
+	}
+
+	return len(digits) >= 13 && len(digits) <= 19 && luhnValid(digits)
+}
+
+// luhnValid reports whether the digits pass the Luhn checksum used by card numbers
+func luhnValid(digits []byte) bool {
+	sum := 0
+	for i := range digits {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+
+	return sum%10 == 0
+}
+
+// truncateString cuts s to at most maxLen bytes on a rune boundary, noting the original length
+func truncateString(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+
+	cut := maxLen
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+
+	return s[:cut] + " (" + strconv.Itoa(len(s)) + " bytes total)"
+}
@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+// sqlFieldMap encodes the fields of SQL into a map
+func sqlFieldMap(query string, args []interface{}, duration time.Duration) map[string]interface{} {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range SQL(query, args, duration) {
+		f.AddTo(enc)
+	}
+
+	return enc.Fields
+}
+
+func TestSQL_Redaction(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		args  []interface{}
+		want  []interface{}
+	}{
+		{
+			name:  "comparison",
+			query: "SELECT id FROM users WHERE email = ? AND u.password_hash = ?",
+			args:  []interface{}{"a@example.com", "hunter2"},
+			want:  []interface{}{`"a@example.com"`, "[REDACTED]"},
+		},
+		{
+			name:  "numbered placeholders",
+			query: `UPDATE users SET "api_token" = $2 WHERE id = $1`,
+			args:  []interface{}{42, "abc"},
+			want:  []interface{}{"42", "[REDACTED]"},
+		},
+		{
+			name:  "insert columns",
+			query: "INSERT INTO users (name, pin, created_at) VALUES (?, ?, NOW()), (?, ?, NOW())",
+			args:  []interface{}{"bob", 1234, "eve", 5678},
+			want:  []interface{}{`"bob"`, "[REDACTED]", `"eve"`, "[REDACTED]"},
+		},
+		{
+			name:  "named argument",
+			query: "SELECT 1 FROM sessions WHERE id = @id AND secret = @secret",
+			args:  []interface{}{sql.Named("id", 7), sql.Named("secret", "s3cr3t")},
+			want:  []interface{}{"7", "[REDACTED]"},
+		},
+		{
+			name:  "sensitive values",
+			query: "SELECT ? , ?, ?, ?, ?",
+			args:  []interface{}{"4111 1111 1111 1111", "Bearer abc", "eyJhbGciOi.eyJzdWIi.sig", nil, []byte{1, 2, 3}},
+			want:  []interface{}{"[REDACTED]", "[REDACTED]", "[REDACTED]", "NULL", "<3 bytes>"},
+		},
+		{
+			name:  "quoted placeholder",
+			query: "SELECT * FROM t WHERE note = 'password = ?' AND name = ?",
+			args:  []interface{}{"ann"},
+			want:  []interface{}{`"ann"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sqlFieldMap(tt.query, tt.args, 0)
+			assert.Equal(t, tt.want, got["args"])
+		})
+	}
+}
+
+func TestSQL_Truncation(t *testing.T) {
+	query := "SELECT *\n\tFROM t WHERE id IN (" + strings.Repeat("1, ", SQLMaxQueryLength) + "1)"
+	got := sqlFieldMap(query, []interface{}{strings.Repeat("x", SQLMaxArgLength+10)}, 0)
+
+	logged := got["query"].(string)
+	assert.True(t, strings.HasPrefix(logged, "SELECT * FROM t WHERE id IN (1, 1,"))
+	assert.True(t, strings.HasSuffix(logged, " bytes total)"))
+	assert.LessOrEqual(t, len(logged), SQLMaxQueryLength+len(" (10000 bytes total)"))
+
+	arg := got["args"].([]interface{})[0].(string)
+	assert.Equal(t, `"`+strings.Repeat("x", SQLMaxArgLength)+` (74 bytes total)"`, arg)
+}
+
+func TestSQL_Duration(t *testing.T) {
+	got := sqlFieldMap("SELECT 1", nil, 1500*time.Microsecond)
+
+	assert.Equal(t, 1.5, got["duration_ms"])
+	assert.Equal(t, "SELECT 1", got["query"])
+	assert.Empty(t, got["args"])
+}
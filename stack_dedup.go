@@ -0,0 +1,113 @@
+package logger
+
+import (
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// stackDedupFrames is the number of innermost frames forming the signature of a stack trace
+const stackDedupFrames = 8
+
+// stackDedupCore is a zapcore.Core dropping stack traces already written within a window
+type stackDedupCore struct {
+	zapcore.Core
+	levelChecked
+	window time.Duration
+	seen   *sync.Map // Time the stack was last written in full, keyed by signature
+}
+
+// WithStackDedup writes a given stack trace at most once per window
+//
+// Stack traces are identified by their innermost frames. During an error storm the first
+// entry carries the full stack trace; the entries with the same stack trace that follow
+// within the window are still written, without their stack trace and with a
+// "stack_suppressed": true field.
+//
+// Parameters:
+//   - window: The duration during which a repeated stack trace is suppressed
+//
+// Returns:
+//   - Option: A function that sets the stack dedup window in the option struct
+func WithStackDedup(window time.Duration) Option {
+	return func(o *option) {
+		o.stackDedupWindow = window
+	}
+}
+
+// newStackDedupCore wraps a core to suppress repeated stack traces
+//
+// Parameters:
+//   - core: The core to wrap
+//   - window: The duration during which a repeated stack trace is suppressed
+//
+// Returns:
+//   - zapcore.Core: A core writing each stack trace at most once per window
+func newStackDedupCore(core zapcore.Core, window time.Duration) zapcore.Core {
+	return &stackDedupCore{Core: core, window: window, seen: &sync.Map{}}
+}
+
+// With adds structured context to the core, sharing the seen stack traces
+func (c *stackDedupCore) With(fields []zapcore.Field) zapcore.Core {
+	return &stackDedupCore{Core: c.Core.With(fields), window: c.window, seen: c.seen}
+}
+
+// Check adds the core so Write can drop the stack trace
+func (c *stackDedupCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write drops the entry's stack trace if it was written within the window
+func (c *stackDedupCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if ent.Stack != "" && c.suppress(stackSignature(ent.Stack), ent.Time) {
+		ent.Stack = ""
+		fields = append([]zapcore.Field{zap.Bool("stack_suppressed", true)}, fields...)
+	}
+
+	return writeEntry(c.Core, ent, fields)
+}
+
+// suppress reports whether a stack trace was written in full within the window before t
+func (c *stackDedupCore) suppress(signature uint64, t time.Time) bool {
+	now := t.UnixNano()
+	v, loaded := c.seen.LoadOrStore(signature, new(atomic.Int64))
+	last := v.(*atomic.Int64)
+
+	for {
+		written := last.Load()
+		if loaded && now-written < c.window.Nanoseconds() {
+			return true
+		}
+		if last.CompareAndSwap(written, now) {
+			return false
+		}
+		// Another entry with the same stack trace was written concurrently
+		loaded = true
+	}
+}
+
+// stackSignature hashes the innermost frames of a stack trace
+func stackSignature(stack string) uint64 {
+	frames := parseStack(stack)
+	if len(frames) > stackDedupFrames {
+		frames = frames[:stackDedupFrames]
+	}
+
+	h := fnv.New64a()
+	for _, frame := range frames {
+		_, _ = h.Write([]byte(frame.Function))
+		_, _ = h.Write([]byte(frame.File))
+		_, _ = h.Write([]byte(strconv.Itoa(frame.Line)))
+	}
+
+	return h.Sum64()
+}
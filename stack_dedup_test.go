@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestStackDedupCore(t *testing.T) {
+	obs, recorded := observer.New(zapcore.DebugLevel)
+	core := newStackDedupCore(obs, time.Minute)
+
+	start := time.Now()
+	stack := "main.handler\n\t/src/app/main.go:21\nmain.main\n\t/src/app/main.go:9"
+	other := "main.worker\n\t/src/app/worker.go:7\nmain.main\n\t/src/app/main.go:12"
+	writes := []struct {
+		stack string
+		at    time.Duration
+	}{
+		{stack, 0},
+		{stack, time.Second},
+		{stack, 30 * time.Second},
+		{other, 31 * time.Second},
+		{stack, 61 * time.Second}, // The window has elapsed
+		{stack, 62 * time.Second},
+	}
+	for _, w := range writes {
+		require.NoError(t, core.Write(zapcore.Entry{Level: ErrorLevel, Time: start.Add(w.at), Message: "failed", Stack: w.stack}, nil))
+	}
+
+	entries := recorded.All()
+	require.Len(t, entries, len(writes))
+	wantFull := []bool{true, false, false, true, true, false}
+	for i, entry := range entries {
+		assert.Equal(t, "failed", entry.Message)
+		if wantFull[i] {
+			assert.Equal(t, writes[i].stack, entry.Stack, i)
+			assert.NotContains(t, entry.ContextMap(), "stack_suppressed", i)
+		} else {
+			assert.Empty(t, entry.Stack, i)
+			assert.Equal(t, true, entry.ContextMap()["stack_suppressed"], i)
+		}
+	}
+}
+
+func TestWithStackDedup(t *testing.T) {
+	opt := &option{}
+	WithStackDedup(time.Minute)(opt)
+
+	obs, recorded := observer.New(zapcore.DebugLevel)
+	logger := &Manager{Zap: zap.New(wrapCore(opt, obs), zap.AddStacktrace(ErrorLevel))}
+	for i := 0; i < 3; i++ {
+		logger.Error(context.Background(), "storm", zap.Int("attempt", i))
+	}
+
+	entries := recorded.All()
+	require.Len(t, entries, 3)
+	assert.NotEmpty(t, entries[0].Stack)
+	for _, entry := range entries[1:] {
+		assert.Empty(t, entry.Stack)
+		assert.Equal(t, true, entry.ContextMap()["stack_suppressed"])
+	}
+}
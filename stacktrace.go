@@ -0,0 +1,245 @@
+package logger
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// compactStackDepth is the maximum number of frames kept by WithCompactErrorStack
+const compactStackDepth = 16
+
+// packageDir is the directory of this package's source files, as recorded in stack traces
+var packageDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+type (
+	// stackFrame is one frame of a parsed stack trace
+	stackFrame struct {
+		Function string
+		File     string
+		Line     int
+	}
+
+	// stackFrames is a parsed stack trace, outermost call last
+	stackFrames []stackFrame
+
+	// structuredStackCore is a zapcore.Core that replaces the stacktrace string with an array of frames
+	structuredStackCore struct {
+		zapcore.Core
+		levelChecked
+		key string // Field key of the frame array
+	}
+
+	// compactStackCore is a zapcore.Core that removes the frames of the runtime and the logging stack
+	compactStackCore struct {
+		zapcore.Core
+		levelChecked
+	}
+)
+
+// WithStructuredStacktrace emits stack traces as an array of {func, file, line} objects
+//
+// The multi-line stacktrace string is replaced by an array field, which is easier to
+// query in Elasticsearch and similar stores. It applies to the entries that carry a
+// stack trace, i.e. those at or above the stacktrace level.
+//
+// Parameters:
+//   - key: The key of the array field, the encoder config's StacktraceKey when empty
+//
+// Returns:
+//   - Option: A function that enables structured stack traces in the option struct
+func WithStructuredStacktrace(key string) Option {
+	return func(o *option) {
+		o.structuredStack = true
+		o.structuredStackKey = key
+	}
+}
+
+// WithCompactErrorStack removes the frames that never help from the stack traces
+//
+// The frames of this package, zap, the runtime and the testing package are dropped and
+// at most the 16 innermost application frames are kept. It applies to the entries that carry
+// a stack trace, i.e. those at or above the stacktrace level, and combines with
+// WithStructuredStacktrace.
+//
+// Returns:
+//   - Option: A function that enables compact stack traces in the option struct
+func WithCompactErrorStack() Option {
+	return func(o *option) {
+		o.compactStack = true
+	}
+}
+
+// newStructuredStackCore wraps a core to emit stack traces as arrays
+//
+// Parameters:
+//   - core: The core to wrap
+//   - key: The key of the array field
+//
+// Returns:
+//   - zapcore.Core: A core emitting structured stack traces
+func newStructuredStackCore(core zapcore.Core, key string) zapcore.Core {
+	return &structuredStackCore{Core: core, key: key}
+}
+
+// With adds structured context to the core
+func (c *structuredStackCore) With(fields []zapcore.Field) zapcore.Core {
+	return &structuredStackCore{Core: c.Core.With(fields), key: c.key}
+}
+
+// Check adds the core so Write can rewrite the stack trace
+func (c *structuredStackCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write replaces the entry's stack trace string with the parsed frames
+func (c *structuredStackCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if ent.Stack != "" {
+		frames := parseStack(ent.Stack)
+		ent.Stack = ""
+		fields = append(fields[:len(fields):len(fields)], zap.Array(c.key, frames))
+	}
+
+	return writeEntry(c.Core, ent, fields)
+}
+
+// newCompactStackCore wraps a core to compact stack traces
+//
+// Parameters:
+//   - core: The core to wrap
+//
+// Returns:
+//   - zapcore.Core: A core writing compact stack traces
+func newCompactStackCore(core zapcore.Core) zapcore.Core {
+	return &compactStackCore{Core: core}
+}
+
+// With adds structured context to the core
+func (c *compactStackCore) With(fields []zapcore.Field) zapcore.Core {
+	return &compactStackCore{Core: c.Core.With(fields)}
+}
+
+// Check adds the core so Write can rewrite the stack trace
+func (c *compactStackCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write removes the unhelpful frames from the entry's stack trace
+func (c *compactStackCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if ent.Stack != "" {
+		ent.Stack = compactStack(parseStack(ent.Stack), compactStackDepth).String()
+	}
+
+	return writeEntry(c.Core, ent, fields)
+}
+
+// compactStack keeps the application frames, up to maxDepth
+//
+// Parameters:
+//   - frames: The frames of a stack trace
+//   - maxDepth: The maximum number of frames kept
+//
+// Returns:
+//   - stackFrames: The application frames
+func compactStack(frames stackFrames, maxDepth int) stackFrames {
+	compact := make(stackFrames, 0, min(len(frames), maxDepth))
+	for _, frame := range frames {
+		if len(compact) == maxDepth {
+			break
+		}
+		if !frame.internal() {
+			compact = append(compact, frame)
+		}
+	}
+
+	return compact
+}
+
+// internal reports whether the frame belongs to this package, zap, the runtime or the testing package
+func (f stackFrame) internal() bool {
+	switch {
+	case strings.HasPrefix(f.Function, "runtime."), strings.HasPrefix(f.Function, "testing."):
+		return true
+	case strings.HasPrefix(f.Function, "go.uber.org/zap"):
+		return true
+	case filepath.Dir(f.File) == packageDir && !strings.HasSuffix(f.File, "_test.go"):
+		return true
+	}
+
+	return false
+}
+
+// String formats the frames like zap, the function followed by a tab-indented file:line
+func (s stackFrames) String() string {
+	var b strings.Builder
+	for i, frame := range s {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(frame.Function + "\n\t" + frame.File + ":" + strconv.Itoa(frame.Line))
+	}
+
+	return b.String()
+}
+
+// parseStack parses a stack trace formatted by zap
+//
+// zap formats each frame as the function name followed by a tab-indented file:line.
+//
+// Parameters:
+//   - stack: The stack trace string
+//
+// Returns:
+//   - stackFrames: The parsed frames
+func parseStack(stack string) stackFrames {
+	lines := strings.Split(strings.TrimSpace(stack), "\n")
+	frames := make(stackFrames, 0, len(lines)/2)
+	for i := 0; i+1 < len(lines); i += 2 {
+		frame := stackFrame{Function: lines[i]}
+
+		location := strings.TrimSpace(lines[i+1])
+		if colon := strings.LastIndex(location, ":"); colon >= 0 {
+			frame.File = location[:colon]
+			frame.Line, _ = strconv.Atoi(location[colon+1:])
+		} else {
+			frame.File = location
+		}
+
+		frames = append(frames, frame)
+	}
+
+	return frames
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler
+func (f stackFrame) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("func", f.Function)
+	enc.AddString("file", f.File)
+	enc.AddInt("line", f.Line)
+	return nil
+}
+
+// MarshalLogArray implements zapcore.ArrayMarshaler
+func (s stackFrames) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, frame := range s {
+		if err := enc.AppendObject(frame); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestStructuredStackCore(t *testing.T) {
+	obs, recorded := observer.New(zapcore.DebugLevel)
+	logger := zap.New(newStructuredStackCore(obs, "stack"), zap.AddStacktrace(ErrorLevel))
+
+	logger.Info("no stack")
+	logger.Error("with stack")
+
+	entries := recorded.All()
+	require.Len(t, entries, 2)
+	assert.NotContains(t, entries[0].ContextMap(), "stack")
+
+	assert.Empty(t, entries[1].Stack)
+	frames, ok := entries[1].ContextMap()["stack"].([]interface{})
+	require.True(t, ok)
+	require.NotEmpty(t, frames)
+
+	top := frames[0].(map[string]interface{})
+	assert.Equal(t, "github.com/sk-pkg/logger.TestStructuredStackCore", top["func"])
+	assert.Contains(t, top["file"], "stacktrace_test.go")
+	assert.Greater(t, top["line"], 0)
+}
+
+func TestParseStack(t *testing.T) {
+	stack := "main.handler\n\t/src/app/main.go:21\nmain.main\n\t/src/app/main.go:9"
+
+	assert.Equal(t, stackFrames{
+		{Function: "main.handler", File: "/src/app/main.go", Line: 21},
+		{Function: "main.main", File: "/src/app/main.go", Line: 9},
+	}, parseStack(stack))
+}
+
+func TestWithCompactErrorStack(t *testing.T) {
+	opt := &option{}
+	WithCompactErrorStack()(opt)
+
+	obs, recorded := observer.New(zapcore.DebugLevel)
+	logger := &Manager{Zap: zap.New(wrapCore(opt, obs), zap.AddStacktrace(ErrorLevel))}
+	logger.Error(context.Background(), "with stack")
+
+	require.Equal(t, 1, recorded.Len())
+	frames := parseStack(recorded.All()[0].Stack)
+	require.NotEmpty(t, frames)
+	assert.Equal(t, "github.com/sk-pkg/logger.TestWithCompactErrorStack", frames[0].Function)
+	for _, frame := range frames {
+		assert.False(t, strings.HasPrefix(frame.Function, "runtime."), frame.Function)
+		assert.False(t, strings.HasPrefix(frame.Function, "testing."), frame.Function)
+		assert.NotContains(t, frame.Function, "(*Manager)")
+	}
+}
+
+func TestCompactStack(t *testing.T) {
+	internal := filepath.Join(packageDir, "logger.go")
+	stack := "github.com/sk-pkg/logger.(*Manager).Error\n\t" + internal + ":10\n" +
+		"main.handler\n\t/src/app/main.go:21\n" +
+		"net/http.HandlerFunc.ServeHTTP\n\t/go/src/net/http/server.go:2166\n" +
+		"runtime.goexit\n\t/go/src/runtime/asm_amd64.s:1695"
+
+	assert.Equal(t,
+		"main.handler\n\t/src/app/main.go:21\nnet/http.HandlerFunc.ServeHTTP\n\t/go/src/net/http/server.go:2166",
+		compactStack(parseStack(stack), 16).String(),
+	)
+	assert.Equal(t, "main.handler\n\t/src/app/main.go:21", compactStack(parseStack(stack), 1).String())
+}
@@ -0,0 +1,160 @@
+package logger
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ErrWriteTimeout is returned when a write is abandoned by WithWriteTimeout
+var ErrWriteTimeout = errors.New("log write timed out")
+
+// timeoutSyncer is a zapcore.WriteSyncer that abandons writes taking longer than a timeout
+type timeoutSyncer struct {
+	zapcore.WriteSyncer
+	timeout  time.Duration
+	inflight chan struct{}  // Holds a token while a write is running
+	timeouts *atomic.Uint64 // Number of abandoned writes
+}
+
+// errorSyncer is a zapcore.WriteSyncer reporting write failures to a callback
+type errorSyncer struct {
+	zapcore.WriteSyncer
+	onError   func(err error)
+	reporting *atomic.Bool // Set while onError runs, guards against recursion
+}
+
+// WithWriteTimeout abandons writes that do not complete within the given duration
+//
+// A write to a blocked sink (e.g. a hung network mount) is abandoned after the timeout
+// instead of hanging the logging goroutine, and counted in WriteTimeouts. While an
+// abandoned write is still blocked, further writes wait at most the timeout for it.
+//
+// Parameters:
+//   - timeout: The maximum duration of a write
+//
+// Returns:
+//   - Option: A function that sets the write timeout in the option struct
+func WithWriteTimeout(timeout time.Duration) Option {
+	return func(o *option) {
+		o.writeTimeout = timeout
+	}
+}
+
+// WithOnError sets a function called whenever writing an entry to an output fails
+//
+// zap only reports failed writes on stderr; the callback makes it possible to raise an alert
+// or switch to a fallback, e.g. when the disk is full. It runs on the logging goroutine.
+// Errors raised while the callback is running, including by entries it logs itself, are not
+// reported again, so the callback cannot recurse.
+//
+// Parameters:
+//   - onError: The function called with the write error
+//
+// Returns:
+//   - Option: A function that sets the write error callback in the option struct
+func WithOnError(onError func(err error)) Option {
+	return func(o *option) {
+		o.onError = onError
+	}
+}
+
+// wrapSyncer applies the optional syncer wrappers configured in the options
+//
+// Parameters:
+//   - opt: The option struct containing configuration
+//   - syncer: The syncer of an output
+//
+// Returns:
+//   - zapcore.WriteSyncer: The syncer wrapped with every enabled feature
+func wrapSyncer(opt *option, syncer zapcore.WriteSyncer) zapcore.WriteSyncer {
+	if opt.writeTimeout > 0 {
+		syncer = &timeoutSyncer{
+			WriteSyncer: syncer,
+			timeout:     opt.writeTimeout,
+			inflight:    make(chan struct{}, 1),
+			timeouts:    &opt.writeTimeouts,
+		}
+	}
+
+	if opt.validateJSON && opt.encoding == "json" {
+		syncer = &validatingSyncer{WriteSyncer: syncer, onInvalid: opt.validateJSONHook}
+	}
+
+	if opt.onError != nil {
+		syncer = &errorSyncer{WriteSyncer: syncer, onError: opt.onError, reporting: &opt.reportingError}
+	}
+
+	return syncer
+}
+
+// cacheSyncer records an output opened by the driver so rebuilding the core reuses it
+//
+// Parameters:
+//   - key: "stdout", the log file pattern or the plain file path
+//   - syncer: The wrapped syncer of the output
+func (o *option) cacheSyncer(key string, syncer zapcore.WriteSyncer) {
+	if o.syncers == nil {
+		o.syncers = make(map[string]zapcore.WriteSyncer)
+	}
+	o.syncers[key] = syncer
+}
+
+// Write implements zapcore.WriteSyncer
+func (s *timeoutSyncer) Write(p []byte) (int, error) {
+	timer := time.NewTimer(s.timeout)
+	defer timer.Stop()
+
+	select {
+	case s.inflight <- struct{}{}:
+	case <-timer.C:
+		s.timeouts.Add(1)
+		return 0, ErrWriteTimeout
+	}
+
+	// The caller reuses p once Write returns, the abandoned write needs its own copy
+	buf := append([]byte(nil), p...)
+	done := make(chan error, 1)
+	go func() {
+		_, err := s.WriteSyncer.Write(buf)
+		<-s.inflight
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	case <-timer.C:
+		s.timeouts.Add(1)
+		return 0, ErrWriteTimeout
+	}
+}
+
+// Write implements zapcore.WriteSyncer
+func (s *errorSyncer) Write(p []byte) (int, error) {
+	n, err := s.WriteSyncer.Write(p)
+	if err != nil && s.reporting.CompareAndSwap(false, true) {
+		defer s.reporting.Store(false)
+		s.onError(err)
+	}
+
+	return n, err
+}
+
+// WriteTimeouts returns the number of writes abandoned by WithWriteTimeout
+//
+// Returns:
+//   - uint64: The number of abandoned writes since the manager was created
+func (m *Manager) WriteTimeouts() uint64 {
+	opt := m.options()
+	if opt == nil {
+		return 0
+	}
+
+	return opt.writeTimeouts.Load()
+}
@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// slowWriter blocks every write until release is closed
+type slowWriter struct {
+	bytes.Buffer
+	release chan struct{}
+}
+
+func (w *slowWriter) Write(p []byte) (int, error) {
+	<-w.release
+	return w.Buffer.Write(p)
+}
+
+func TestTimeoutSyncer(t *testing.T) {
+	opt := &option{writeTimeout: 20 * time.Millisecond}
+	slow := &slowWriter{release: make(chan struct{})}
+	syncer := wrapSyncer(opt, zapcore.AddSync(slow))
+
+	start := time.Now()
+	_, err := syncer.Write([]byte("first\n"))
+	assert.ErrorIs(t, err, ErrWriteTimeout)
+	assert.Less(t, time.Since(start), time.Second)
+
+	// The first write is still blocked, the second one gives up as well
+	_, err = syncer.Write([]byte("second\n"))
+	assert.ErrorIs(t, err, ErrWriteTimeout)
+	assert.Equal(t, uint64(2), opt.writeTimeouts.Load())
+
+	close(slow.release)
+	assert.Eventually(t, func() bool {
+		n, err := syncer.Write([]byte("third\n"))
+		return err == nil && n == len("third\n")
+	}, time.Second, 5*time.Millisecond)
+}
+
+// failingWriter fails every write
+type failingWriter struct {
+	err error
+}
+
+func (w failingWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
+
+func TestWithOnError(t *testing.T) {
+	errDiskFull := errors.New("disk full")
+
+	var logger *zap.Logger
+	var reported []error
+	opt := &option{}
+	WithOnError(func(err error) {
+		reported = append(reported, err)
+		// Logging from the callback fails as well, but must not recurse
+		logger.Error("write failed", zap.Error(err))
+	})(opt)
+
+	syncer := wrapSyncer(opt, zapcore.AddSync(failingWriter{err: errDiskFull}))
+	logger = zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(DefaultEncoderConfig), syncer, InfoLevel),
+		zap.ErrorOutput(zapcore.AddSync(io.Discard)))
+
+	logger.Info("first")
+	logger.Info("second")
+
+	require.Len(t, reported, 2)
+	assert.ErrorIs(t, reported[0], errDiskFull)
+	assert.ErrorIs(t, reported[1], errDiskFull)
+}
@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// callerThrottleCore is a zapcore.Core dropping the entries of callers logging too often
+type callerThrottleCore struct {
+	zapcore.Core
+	levelChecked
+	maxPerSec uint64
+	counters  *sync.Map // Per-second counters keyed by caller
+}
+
+// WithCallerThrottle caps the number of entries per second written by a single call site
+//
+// It protects against log storms caused by a logging call in a hot loop. Once a call site
+// (file:line) exceeds maxPerSec entries within a second, its further entries are dropped
+// until the next second and a single Warn entry "log throttling engaged for caller X" is
+// written. The budget resets every second.
+//
+// Parameters:
+//   - maxPerSec: The maximum number of entries per call site and second
+//
+// Returns:
+//   - Option: A function that sets the caller throttle in the option struct
+func WithCallerThrottle(maxPerSec int) Option {
+	return func(o *option) {
+		o.callerThrottle = maxPerSec
+	}
+}
+
+// newCallerThrottleCore wraps a core to throttle the callers exceeding maxPerSec entries per second
+//
+// Parameters:
+//   - core: The core to wrap
+//   - maxPerSec: The maximum number of entries per call site and second
+//
+// Returns:
+//   - zapcore.Core: A core throttling noisy callers
+func newCallerThrottleCore(core zapcore.Core, maxPerSec int) zapcore.Core {
+	return &callerThrottleCore{Core: core, maxPerSec: uint64(maxPerSec), counters: &sync.Map{}}
+}
+
+// With adds structured context to the core, sharing the counters
+func (c *callerThrottleCore) With(fields []zapcore.Field) zapcore.Core {
+	return &callerThrottleCore{Core: c.Core.With(fields), maxPerSec: c.maxPerSec, counters: c.counters}
+}
+
+// Check defers the decision to Write, where the caller is known
+func (c *callerThrottleCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write writes the entry unless its caller exceeded its budget for the current second
+func (c *callerThrottleCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	key := callerKey(ent)
+	v, _ := c.counters.LoadOrStore(key, &samplingCounter{})
+
+	n := v.(*samplingCounter).incCheckReset(ent.Time, time.Second)
+	if n <= c.maxPerSec {
+		return writeEntry(c.Core, ent, fields)
+	}
+
+	if n == c.maxPerSec+1 {
+		warning := zapcore.Entry{
+			Level:      zapcore.WarnLevel,
+			Time:       ent.Time,
+			LoggerName: ent.LoggerName,
+			Message:    "log throttling engaged for caller " + key,
+		}
+		return writeEntry(c.Core, warning, []zapcore.Field{zap.Uint64("max_per_sec", c.maxPerSec)})
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestCallerThrottleCore(t *testing.T) {
+	obs, recorded := observer.New(zapcore.DebugLevel)
+	core := newCallerThrottleCore(obs, 3)
+
+	noisy := zapcore.NewEntryCaller(0, "service/loop.go", 10, true)
+	quiet := zapcore.NewEntryCaller(0, "service/api.go", 20, true)
+
+	write := func(caller zapcore.EntryCaller, ts time.Time) {
+		ent := zapcore.Entry{Level: zapcore.InfoLevel, Time: ts, Message: "tick", Caller: caller}
+		if ce := core.Check(ent, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	now := time.Now()
+	for i := 0; i < 100; i++ {
+		write(noisy, now)
+	}
+	write(quiet, now)
+	write(quiet, now)
+
+	perCaller := map[string]int{}
+	var warnings []string
+	for _, entry := range recorded.All() {
+		if entry.Level == zapcore.WarnLevel {
+			warnings = append(warnings, entry.Message)
+			continue
+		}
+		perCaller[entry.Caller.File]++
+	}
+	assert.Equal(t, 3, perCaller["service/loop.go"])
+	assert.Equal(t, 2, perCaller["service/api.go"])
+	assert.Equal(t, []string{"log throttling engaged for caller service/loop.go:10"}, warnings)
+
+	// The budget resets after a second
+	recorded.TakeAll()
+	write(noisy, now.Add(1100*time.Millisecond))
+	assert.Equal(t, 1, recorded.Len())
+}
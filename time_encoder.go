@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// WithTimeFormat sets the layout used to format entry timestamps
+//
+// Parameters:
+//   - layout: A time layout as accepted by time.Time.Format
+//
+// Returns:
+//   - Option: A function that sets the time format in the option struct
+func WithTimeFormat(layout string) Option {
+	return func(o *option) {
+		o.timeFormat = layout
+	}
+}
+
+// WithTimezone converts entry timestamps to the given location before formatting
+//
+// It wraps the configured time encoder, so it composes with WithTimeFormat and
+// with a custom EncodeTime set through WithEncoderConfig.
+//
+// Parameters:
+//   - loc: The location timestamps are converted to
+//
+// Returns:
+//   - Option: A function that sets the timezone in the option struct
+func WithTimezone(loc *time.Location) Option {
+	return func(o *option) {
+		o.timezone = loc
+	}
+}
+
+// WithUTC emits entry timestamps in UTC
+//
+// Returns:
+//   - Option: A function that sets the timezone to UTC in the option struct
+func WithUTC() Option {
+	return WithTimezone(time.UTC)
+}
+
+// timeEncoder returns the time encoder resolved from the encoder config, time format and timezone
+//
+// Parameters:
+//   - opt: The option struct containing configuration
+//
+// Returns:
+//   - zapcore.TimeEncoder: The time encoder to use, nil if the encoder config has none
+func timeEncoder(opt *option) zapcore.TimeEncoder {
+	encode := opt.encoderConfig.EncodeTime
+	if opt.timeFormat != "" {
+		encode = zapcore.TimeEncoderOfLayout(opt.timeFormat)
+	}
+
+	if encode == nil || opt.timezone == nil {
+		return encode
+	}
+
+	loc := opt.timezone
+	return func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+		encode(t.In(loc), enc)
+	}
+}
@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestTimeEncoder_Timezone(t *testing.T) {
+	// A fixed non-UTC zone stands in for the host's local time
+	ts := time.Date(2024, 1, 2, 23, 30, 0, 0, time.FixedZone("UTC+8", 8*60*60))
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{
+			name: "UTC",
+			opts: []Option{WithUTC()},
+			want: "2024-01-02T15:30:00.000Z",
+		},
+		{
+			name: "Timezone with time format",
+			opts: []Option{WithTimeFormat(time.RFC3339), WithTimezone(time.FixedZone("UTC-5", -5*60*60))},
+			want: "2024-01-02T10:30:00-05:00",
+		},
+		{
+			name: "Time format without timezone",
+			opts: []Option{WithTimeFormat("2006-01-02 15:04")},
+			want: "2024-01-02 23:30",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opt := &option{encoderConfig: DefaultEncoderConfig}
+			for _, f := range tt.opts {
+				f(opt)
+			}
+
+			config := DefaultEncoderConfig
+			config.EncodeTime = timeEncoder(opt)
+			buf, err := zapcore.NewJSONEncoder(config).EncodeEntry(zapcore.Entry{Time: ts, Message: "tz"}, nil)
+			require.NoError(t, err)
+
+			var got map[string]interface{}
+			require.NoError(t, json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &got))
+			assert.Equal(t, tt.want, got["T"])
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// validatingSyncer is a zapcore.WriteSyncer checking that every line written is valid JSON
+type validatingSyncer struct {
+	zapcore.WriteSyncer
+	onInvalid func(line []byte) // Called with each invalid line, nil panics instead
+}
+
+// WithValidateJSON checks that every line written is valid JSON and panics otherwise
+//
+// This is meant for tests and CI, where it guarantees the output stays valid NDJSON,
+// e.g. that no value carries an unescaped control character. It only applies to the
+// "json" encoding and adds a full parse of every entry, so it should not be used in production.
+//
+// Returns:
+//   - Option: A function that enables JSON validation in the option struct
+func WithValidateJSON() Option {
+	return func(o *option) {
+		o.validateJSON = true
+	}
+}
+
+// WithValidateJSONHook checks that every line written is valid JSON, calling hook otherwise
+//
+// Like WithValidateJSON, but invalid lines are reported to the hook instead of panicking.
+// The line is still written after the hook returns.
+//
+// Parameters:
+//   - hook: The function called with each invalid line
+//
+// Returns:
+//   - Option: A function that enables JSON validation with a hook in the option struct
+func WithValidateJSONHook(hook func(line []byte)) Option {
+	return func(o *option) {
+		o.validateJSON = true
+		o.validateJSONHook = hook
+	}
+}
+
+// Write validates each line of p before writing it
+func (s *validatingSyncer) Write(p []byte) (int, error) {
+	for _, line := range bytes.Split(p, []byte("\n")) {
+		if len(line) == 0 || json.Valid(line) {
+			continue
+		}
+
+		if s.onInvalid == nil {
+			panic(fmt.Sprintf("logger: invalid JSON line: %q", line))
+		}
+		s.onInvalid(line)
+	}
+
+	return s.WriteSyncer.Write(p)
+}
@@ -0,0 +1,60 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestValidatingSyncer(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		invalid []string
+	}{
+		{"valid line", "{\"M\":\"ok\"}\n", nil},
+		{"escaped control character", "{\"M\":\"a\\tb\"}\n", nil},
+		{"raw control character", "{\"M\":\"a\tb\"}\n", []string{"{\"M\":\"a\tb\"}"}},
+		{"unterminated object", "{\"M\":\"ok\"\n", []string{"{\"M\":\"ok\""}},
+		{"one bad line of two", "{\"M\":1}\n{\"M\":\n", []string{"{\"M\":"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			var invalid []string
+			syncer := &validatingSyncer{
+				WriteSyncer: zapcore.AddSync(&out),
+				onInvalid:   func(line []byte) { invalid = append(invalid, string(line)) },
+			}
+
+			n, err := syncer.Write([]byte(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.input), n)
+			assert.Equal(t, tt.input, out.String())
+			assert.Equal(t, tt.invalid, invalid)
+		})
+	}
+}
+
+func TestValidatingSyncer_Panics(t *testing.T) {
+	syncer := &validatingSyncer{WriteSyncer: zapcore.AddSync(&bytes.Buffer{})}
+
+	assert.Panics(t, func() {
+		_, _ = syncer.Write([]byte("{\"M\":\"a\x01b\"}\n"))
+	})
+}
+
+func TestNew_ValidateJSON(t *testing.T) {
+	var invalid int
+	logger, err := New(WithValidateJSONHook(func([]byte) { invalid++ }))
+	require.NoError(t, err)
+
+	logger.Info(context.Background(), "control\x01characters\tescaped", zap.String("value", "\x00\n\x1b"))
+	assert.Zero(t, invalid)
+}
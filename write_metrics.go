@@ -0,0 +1,179 @@
+package logger
+
+import (
+	"math/bits"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// latencySubBuckets is the number of histogram buckets per power of two, a 25% resolution
+const latencySubBuckets = 4
+
+type (
+	// WriteStats holds the aggregate latency of the writes recorded by WithAsyncMetrics
+	WriteStats struct {
+		Count uint64        // Number of recorded writes
+		P50   time.Duration // Median write latency
+		P99   time.Duration // 99th percentile write latency
+		Max   time.Duration // Slowest write
+	}
+
+	// latencyHistogram is a lock-free log-linear histogram of durations
+	//
+	// Each power of two is split into latencySubBuckets buckets, so quantiles are
+	// accurate to 25% with a fixed 2 KiB of counters.
+	latencyHistogram struct {
+		buckets [64 * latencySubBuckets]atomic.Uint64
+		count   atomic.Uint64
+		max     atomic.Int64
+	}
+
+	// writeMetricsCore is a zapcore.Core recording the duration of every write
+	writeMetricsCore struct {
+		zapcore.Core
+		levelChecked
+		latency *latencyHistogram
+	}
+)
+
+// WithAsyncMetrics records how long each entry takes to be written, exposed by Stats
+//
+// The duration covers encoding and writing the entry to every output, so a slow sink
+// shows up as a higher latency. Recording is a few atomic operations on a fixed-size
+// histogram and needs no metrics library.
+//
+// Returns:
+//   - Option: A function that enables the write metrics in the option struct
+func WithAsyncMetrics() Option {
+	return func(o *option) {
+		o.writeLatency = &latencyHistogram{}
+	}
+}
+
+// newWriteMetricsCore wraps a core with write latency recording
+//
+// Parameters:
+//   - core: The core to wrap
+//   - latency: The histogram receiving the durations
+//
+// Returns:
+//   - zapcore.Core: A core recording the duration of its writes
+func newWriteMetricsCore(core zapcore.Core, latency *latencyHistogram) zapcore.Core {
+	return &writeMetricsCore{Core: core, latency: latency}
+}
+
+// With adds structured context to the core, sharing the histogram
+func (c *writeMetricsCore) With(fields []zapcore.Field) zapcore.Core {
+	return &writeMetricsCore{Core: c.Core.With(fields), latency: c.latency}
+}
+
+// Check adds the core if the entry's level is enabled
+func (c *writeMetricsCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write writes the entry and records how long it took
+func (c *writeMetricsCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	start := time.Now()
+	err := writeEntry(c.Core, ent, fields)
+	c.latency.record(time.Since(start))
+
+	return err
+}
+
+// record adds a duration to the histogram
+func (h *latencyHistogram) record(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+
+	h.buckets[latencyBucket(uint64(d))].Add(1)
+	h.count.Add(1)
+	for {
+		max := h.max.Load()
+		if int64(d) <= max || h.max.CompareAndSwap(max, int64(d)) {
+			return
+		}
+	}
+}
+
+// stats returns the count, the median and 99th percentile, and the maximum
+func (h *latencyHistogram) stats() WriteStats {
+	var counts [len(h.buckets)]uint64
+	var total uint64
+	for i := range h.buckets {
+		counts[i] = h.buckets[i].Load()
+		total += counts[i]
+	}
+
+	stats := WriteStats{Count: total, Max: time.Duration(h.max.Load())}
+	if total == 0 {
+		return stats
+	}
+
+	stats.P50 = min(quantile(counts[:], total, 0.50), stats.Max)
+	stats.P99 = min(quantile(counts[:], total, 0.99), stats.Max)
+
+	return stats
+}
+
+// quantile returns the upper bound of the bucket holding the q-quantile
+func quantile(counts []uint64, total uint64, q float64) time.Duration {
+	rank := uint64(q*float64(total-1)) + 1
+	var seen uint64
+	for i, n := range counts {
+		seen += n
+		if seen >= rank {
+			return time.Duration(latencyBucketUpper(i))
+		}
+	}
+
+	return time.Duration(latencyBucketUpper(len(counts) - 1))
+}
+
+// latencyBucket returns the histogram bucket of a duration in nanoseconds
+func latencyBucket(ns uint64) int {
+	if ns < latencySubBuckets {
+		return int(ns)
+	}
+
+	exp := bits.Len64(ns) - 1 // At least 2, the exponent of the highest bit
+	sub := int(ns>>(exp-2)) & (latencySubBuckets - 1)
+
+	return (exp-1)*latencySubBuckets + sub
+}
+
+// latencyBucketUpper returns the largest duration in nanoseconds falling in a bucket
+func latencyBucketUpper(bucket int) uint64 {
+	if bucket < latencySubBuckets {
+		return uint64(bucket)
+	}
+
+	exp := bucket/latencySubBuckets + 1
+	sub := uint64(bucket % latencySubBuckets)
+
+	return (latencySubBuckets+sub+1)<<(exp-2) - 1
+}
+
+// Stats returns the write latency recorded by WithAsyncMetrics
+//
+// The percentiles are estimated from a histogram and rounded up to at most 25% above the
+// actual value. The statistics restart when the manager is reconfigured. All values are
+// zero when the metrics are not enabled.
+//
+// Returns:
+//   - WriteStats: The number of writes and their latency percentiles
+func (m *Manager) Stats() WriteStats {
+	opt := m.options()
+	if opt == nil || opt.writeLatency == nil {
+		return WriteStats{}
+	}
+
+	return opt.writeLatency.stats()
+}
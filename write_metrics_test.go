@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// delayWriter sleeps before every write
+type delayWriter struct {
+	delay time.Duration
+}
+
+func (w delayWriter) Write(p []byte) (int, error) {
+	time.Sleep(w.delay)
+	return len(p), nil
+}
+
+func TestWithAsyncMetrics_SlowWriter(t *testing.T) {
+	opt := &option{}
+	WithAsyncMetrics()(opt)
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(DefaultEncoderConfig), zapcore.AddSync(delayWriter{delay: 20 * time.Millisecond}), InfoLevel)
+	logger := &Manager{Zap: zap.New(wrapCore(opt, core)), opt: opt}
+
+	for i := 0; i < 5; i++ {
+		logger.Info(context.Background(), "slow")
+	}
+	logger.Log(context.Background(), DebugLevel, "not written")
+
+	stats := logger.Stats()
+	assert.Equal(t, uint64(5), stats.Count)
+	assert.GreaterOrEqual(t, stats.P50, 20*time.Millisecond)
+	assert.GreaterOrEqual(t, stats.P99, stats.P50)
+	assert.LessOrEqual(t, stats.P99, stats.Max)
+	assert.Less(t, stats.Max, time.Second)
+}
+
+func TestWithAsyncMetrics_Disabled(t *testing.T) {
+	logger := &Manager{Zap: zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(DefaultEncoderConfig), zapcore.AddSync(io.Discard), InfoLevel))}
+
+	logger.Info(context.Background(), "fast")
+	assert.Equal(t, WriteStats{}, logger.Stats())
+}
+
+func TestLatencyHistogram_Quantiles(t *testing.T) {
+	h := &latencyHistogram{}
+	for i := 1; i <= 100; i++ {
+		h.record(time.Duration(i) * time.Millisecond)
+	}
+
+	stats := h.stats()
+	assert.Equal(t, uint64(100), stats.Count)
+	assert.Equal(t, 100*time.Millisecond, stats.Max)
+	assert.InDelta(t, float64(50*time.Millisecond), float64(stats.P50), float64(50*time.Millisecond)/4)
+	assert.InDelta(t, float64(99*time.Millisecond), float64(stats.P99), float64(99*time.Millisecond)/4)
+
+	for ns := uint64(0); ns < 1<<12; ns++ {
+		assert.LessOrEqual(t, ns, latencyBucketUpper(latencyBucket(ns)))
+	}
+}